@@ -0,0 +1,487 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+/*
+Diff returns the difference of a and b that is.Equal reports with the default options,
+or the empty string if a and b are equal. Use Diff to embed the difference
+in the custom failure messages or logs.
+
+		func TestDiff(t *testing.T) {
+			if diff := is.Diff(myGifts(), herWishlist()); diff != "" {
+				t.Fatalf("wrong gifts: %s", diff)
+			}
+		}
+
+Will output:
+
+		wrong gifts: slices differ at index 1: socks != ring
+			[flowers socks] != [flowers ring]
+*/
+func Diff(a, b interface{}) string {
+	is := new(Is)
+	if equal, _ := is.equal(a, b); equal {
+		return ""
+	}
+	return is.difference(a, b)
+}
+
+// diffContext is the number of unchanged lines surrounding each hunk.
+const diffContext = 3
+
+// edit is a single line of a line diff. op is one of ' ', '-' and '+'.
+type edit struct {
+	op   byte
+	line string
+}
+
+// isMultiline reports whether a or b spans more than one line.
+func isMultiline(a, b string) bool {
+	return strings.Contains(a, "\n") || strings.Contains(b, "\n")
+}
+
+// splitLines splits s after each newline, so every line but
+// possibly the last one keeps its newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edits turning a into b based on their longest common subsequence.
+func diffLines(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+	return edits
+}
+
+// unifiedDiff returns the unified diff of a and b with @@ hunk headers,
+// so the output can be pasted into diff viewing tools.
+func unifiedDiff(a, b string) string {
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	var buf strings.Builder
+	buf.WriteString("--- a\n+++ b")
+
+	aLine, bLine := 1, 1 // line number of edits[i] in a and b
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			aLine++
+			bLine++
+			i++
+			continue
+		}
+
+		// merge the following changes separated by at most 2*diffContext unchanged lines
+		last := i
+		for j := i + 1; j < len(edits) && j-last <= 2*diffContext+1; j++ {
+			if edits[j].op != ' ' {
+				last = j
+			}
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := last + 1 + diffContext
+		if end > len(edits) {
+			end = len(edits)
+		}
+		aStart, bStart := aLine-(i-start), bLine-(i-start)
+
+		var hunk strings.Builder
+		aCount, bCount := 0, 0
+		for _, e := range edits[start:end] {
+			switch e.op {
+			case ' ':
+				aCount++
+				bCount++
+			case '-':
+				aCount++
+			case '+':
+				bCount++
+			}
+			hunk.WriteByte('\n')
+			hunk.WriteByte(e.op)
+			hunk.WriteString(strings.TrimSuffix(e.line, "\n"))
+			if !strings.HasSuffix(e.line, "\n") {
+				hunk.WriteString("\n\\ No newline at end of file")
+			}
+		}
+
+		fmt.Fprintf(&buf, "\n@@ -%s +%s @@", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		buf.WriteString(hunk.String())
+
+		for _, e := range edits[i:end] {
+			if e.op != '+' {
+				aLine++
+			}
+			if e.op != '-' {
+				bLine++
+			}
+		}
+		i = end
+	}
+	return buf.String()
+}
+
+// hunkRange formats the start and count of a hunk header range.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// longSlice is the length from which is.Equal collapses the common head and tail of the slices.
+const longSlice = 20
+
+// elemEqual reports whether the elements or map values a and b are deeply equal, or equal under the cmp options.
+// The comparers and the compare timeout apply to the operands of is.Equal only, like the comparison itself.
+func (is *Is) elemEqual(a, b reflect.Value) bool {
+	va, vb := a.Interface(), b.Interface()
+	return reflect.DeepEqual(va, vb) || len(is.cmpOptions) > 0 && cmpEqual(va, vb, is.cmpOptions)
+}
+
+// sliceDiff reports the first difference in the middle of the long slices or arrays a and b
+// after their common head and tail, it's not ok if a and b are short or share neither.
+func (is *Is) sliceDiff(a, b reflect.Value) (string, bool) {
+	if a.Len() < longSlice && b.Len() < longSlice {
+		return "", false
+	}
+
+	shorter := a.Len()
+	if b.Len() < shorter {
+		shorter = b.Len()
+	}
+
+	head := 0
+	for head < shorter && is.elemEqual(a.Index(head), b.Index(head)) {
+		head++
+	}
+	tail := 0
+	for tail < shorter-head && is.elemEqual(a.Index(a.Len()-1-tail), b.Index(b.Len()-1-tail)) {
+		tail++
+	}
+	if head == 0 && tail == 0 {
+		return "", false
+	}
+
+	// the middle is compared index by index, the extra elements of the longer one differ as well
+	ma, mb := a.Len()-head-tail, b.Len()-head-tail
+	differences := 0
+	first := ""
+	for i := 0; i < ma || i < mb; i++ {
+		var va, vb string
+		switch {
+		case i >= ma:
+			va, vb = "<end>", is.format(b.Index(head+i).Interface())
+		case i >= mb:
+			va, vb = is.format(a.Index(head+i).Interface()), "<end>"
+		case is.elemEqual(a.Index(head+i), b.Index(head+i)):
+			continue
+		default:
+			va, vb = is.format(a.Index(head+i).Interface()), is.format(b.Index(head+i).Interface())
+		}
+		if differences == 0 {
+			first = fmt.Sprintf("index %d: %s != %s", head+i, va, vb)
+		}
+		differences++
+	}
+
+	diff := fmt.Sprintf("slices share first %d and last %d elements; differ in middle: %s", head, tail, first)
+	switch more := differences - 1; more {
+	case 0:
+	case 1:
+		diff += " (1 more difference)"
+	default:
+		diff += fmt.Sprintf(" (%d more differences)", more)
+	}
+	return diff, true
+}
+
+// listDiff reports the first differing index of the slices or arrays a and b of the same length,
+// or their lengths otherwise. The whole operands follow on the next line if both are short.
+// It's not ok if no element differs.
+func (is *Is) listDiff(a, b reflect.Value) (string, bool) {
+	var diff string
+	if a.Len() != b.Len() {
+		diff = fmt.Sprintf("len %d != len %d", a.Len(), b.Len())
+	} else {
+		differences := 0
+		for i := 0; i < a.Len(); i++ {
+			if is.elemEqual(a.Index(i), b.Index(i)) {
+				continue
+			}
+			va, vb := a.Index(i).Interface(), b.Index(i).Interface()
+			if differences == 0 {
+				diff = fmt.Sprintf("slices differ at index %d: %s != %s", i, is.format(va), is.format(vb))
+			}
+			differences++
+		}
+
+		switch more := differences - 1; more {
+		case -1:
+			return "", false
+		case 0:
+		case 1:
+			diff += " (1 more difference)"
+		default:
+			diff += fmt.Sprintf(" (%d more differences)", more)
+		}
+	}
+
+	if a.Len() < longSlice && b.Len() < longSlice {
+		diff += "\n\t" + is.mismatch(is.format(a.Interface()), is.format(b.Interface()))
+	}
+	return diff, true
+}
+
+// mapDifferences is the maximum number of the differing keys reported by mapDiff.
+const mapDifferences = 10
+
+// mapDiff reports the keys of the maps a and b whose values differ,
+// followed by the keys of b missing from a and the extra keys of a, each sorted by their rendering.
+// It's not ok if no key differs.
+func (is *Is) mapDiff(a, b reflect.Value) (string, bool) {
+	var differ, missing, extra []string
+	for _, key := range is.sortedKeys(a) {
+		va, vb := a.MapIndex(key), b.MapIndex(key)
+		switch {
+		case !vb.IsValid():
+			extra = append(extra, "extra key "+is.mapKey(key.Interface()))
+		case !is.elemEqual(va, vb):
+			differ = append(differ, fmt.Sprintf("at key %s: %s != %s",
+				is.mapKey(key.Interface()), is.format(va.Interface()), is.format(vb.Interface())))
+		}
+	}
+	for _, key := range is.sortedKeys(b) {
+		if !a.MapIndex(key).IsValid() {
+			missing = append(missing, "missing key "+is.mapKey(key.Interface()))
+		}
+	}
+
+	differences := append(append(differ, missing...), extra...)
+	if len(differences) == 0 {
+		return "", false
+	}
+
+	more := len(differences) - mapDifferences
+	if more > 0 {
+		differences = differences[:mapDifferences]
+	}
+
+	diff := "map differs: "
+	if len(differ) > 0 {
+		diff = "map differs "
+	}
+	diff += strings.Join(differences, "; ")
+	switch {
+	case more == 1:
+		diff += " (1 more difference)"
+	case more > 1:
+		diff += fmt.Sprintf(" (%d more differences)", more)
+	}
+	return diff, true
+}
+
+// sortedKeys returns the keys of the map m sorted by their value if they are numbers or strings,
+// or by their rendering in the failure message otherwise.
+func (c *config) sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		switch ki, kj := keys[i], keys[j]; numberKind(ki) {
+		case reflect.Int64:
+			return ki.Int() < kj.Int()
+		case reflect.Uint64:
+			return ki.Uint() < kj.Uint()
+		case reflect.Float64:
+			return ki.Float() < kj.Float()
+		}
+		if m.Type().Key().Kind() == reflect.String {
+			return keys[i].String() < keys[j].String()
+		}
+		return c.mapKey(keys[i].Interface()) < c.mapKey(keys[j].Interface())
+	})
+	return keys
+}
+
+// isUnicode reports whether a or b has non-ASCII characters.
+func isUnicode(a, b string) bool {
+	for _, r := range a + b {
+		if r >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// runeDiff reports the rune index and the code points of the first difference of a and b.
+// The runes are compared by their bytes, so the different invalid bytes differ as well.
+// It's not ok if a and b are equal.
+func runeDiff(a, b string) (string, bool) {
+	for i := 0; a != "" || b != ""; i++ {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		switch {
+		case a == "":
+			return fmt.Sprintf("differ at rune %d: <end> != %s", i, codePoint(rb, b[:nb])), true
+		case b == "":
+			return fmt.Sprintf("differ at rune %d: %s != <end>", i, codePoint(ra, a[:na])), true
+		case a[:na] != b[:nb]:
+			return fmt.Sprintf("differ at rune %d: %s != %s", i, codePoint(ra, a[:na]), codePoint(rb, b[:nb])), true
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return "", false
+}
+
+// codePoint renders the rune r decoded from s, or the byte of s if it isn't valid UTF-8.
+func codePoint(r rune, s string) string {
+	if r == utf8.RuneError && len(s) == 1 {
+		return fmt.Sprintf("byte %#02x", s[0])
+	}
+	return fmt.Sprintf("%q (%U)", r, r)
+}
+
+// isDiffable reports whether v is a struct, map, slice or array, or a pointer to one of them.
+func isDiffable(v interface{}) bool {
+	typ := reflect.TypeOf(v)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// exportAll compares unexported fields as well to match reflect.DeepEqual.
+var exportAll = cmp.Exporter(func(reflect.Type) bool { return true })
+
+// ignoreSync ignores the synchronization primitives of the lazily-initialized values.
+var ignoreSync = cmp.FilterPath(func(p cmp.Path) bool {
+	switch p.Last().Type() {
+	case onceType, mutexType, rwMutexType:
+		return true
+	}
+	return false
+}, cmp.Ignore())
+
+var (
+	onceType    = reflect.TypeOf((*sync.Once)(nil)).Elem()
+	mutexType   = reflect.TypeOf((*sync.Mutex)(nil)).Elem()
+	rwMutexType = reflect.TypeOf((*sync.RWMutex)(nil)).Elem()
+)
+
+// cmpEqual reports whether a and b are equal by cmp.Equal with opts.
+func cmpEqual(a, b interface{}, opts []cmp.Option) bool {
+	return cmp.Equal(a, b, append([]cmp.Option{exportAll}, opts...)...)
+}
+
+// cmpDiff returns the diff of a and b by cmp.Diff with opts, indented by a tab.
+func cmpDiff(a, b interface{}, opts []cmp.Option) string {
+	diff := cmp.Diff(a, b, append([]cmp.Option{exportAll}, opts...)...)
+	if diff == "" {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	return "\t" + strings.Join(lines, "\n\t")
+}
+
+// graphDiff returns the differences of the edges of every node in got and want, sorted by node.
+func graphDiff(got, want map[string][]string) []string {
+	var nodes []string
+	for node := range got {
+		nodes = append(nodes, node)
+	}
+	for node := range want {
+		if _, ok := got[node]; !ok {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+
+	var diffs []string
+	for _, node := range nodes {
+		g, inGot := got[node]
+		w, inWant := want[node]
+		switch {
+		case !inGot:
+			diffs = append(diffs, fmt.Sprintf("node %q: missing != %v", node, edgeSet(w)))
+		case !inWant:
+			diffs = append(diffs, fmt.Sprintf("node %q: %v != missing", node, edgeSet(g)))
+		case !reflect.DeepEqual(edgeSet(g), edgeSet(w)):
+			diffs = append(diffs, fmt.Sprintf("node %q: edges %v != %v", node, edgeSet(g), edgeSet(w)))
+		}
+	}
+	return diffs
+}
+
+// edgeSet returns the sorted edges without duplicates.
+func edgeSet(edges []string) []string {
+	set := make([]string, 0, len(edges))
+	seen := make(map[string]bool, len(edges))
+	for _, e := range edges {
+		if !seen[e] {
+			seen[e] = true
+			set = append(set, e)
+		}
+	}
+	sort.Strings(set)
+	return set
+}
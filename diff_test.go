@@ -0,0 +1,469 @@
+package is_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/billyzaelani/is"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEqualMultiline(t *testing.T) {
+	prefix := "is.Equal: strings differ\n--- a\n+++ b\n"
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal("one\ntwo\n", "one\ntwo\n") }},
+		{"changed line", fail, prefix + "@@ -1,3 +1,3 @@\n one\n-two\n+2\n three",
+			func(is *assert.Is) { is.Equal("one\ntwo\nthree\n", "one\n2\nthree\n") }},
+		{"added line", fail, prefix + "@@ -1,2 +1,3 @@\n one\n+two\n three",
+			func(is *assert.Is) { is.Equal("one\nthree\n", "one\ntwo\nthree\n") }},
+		{"removed line", fail, prefix + "@@ -1 +0,0 @@\n-one",
+			func(is *assert.Is) { is.Equal("one\n", "") }},
+		{"no newline at end of file", fail, prefix + "@@ -1,2 +1,2 @@\n one\n-two\n+two\n\\ No newline at end of file",
+			func(is *assert.Is) { is.Equal("one\ntwo\n", "one\ntwo") }},
+		{"separate hunks", fail, prefix +
+			"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+			"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve",
+			func(is *assert.Is) {
+				is.Equal("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n")
+			}},
+		{"merged hunks", fail, prefix +
+			"@@ -1,9 +1,9 @@\n-1\n+one\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9",
+			func(is *assert.Is) { is.Equal("1\n2\n3\n4\n5\n6\n7\n8\n9\n", "one\n2\n3\n4\n5\n6\n7\neight\n9\n") }},
+		{"with comment", fail, "is.Equal: strings differ // render the letter\n--- a\n+++ b\n" +
+			"@@ -1,2 +1,2 @@\n-Dear\n+Hi\n girl",
+			func(is *assert.Is) { is.Equal("Dear\ngirl\n", "Hi\ngirl\n") /* render the letter */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEqualUnicode(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal("café", "café") }},
+		{"differ at multi-byte rune", fail, prefix + `differ at rune 3: 'é' (U+00E9) != 'e' (U+0065)`,
+			func(is *assert.Is) { is.Equal("café", "cafe") }},
+		{"differ after multi-byte rune", fail, prefix + `differ at rune 1: '界' (U+754C) != '人' (U+4EBA) // not the world`,
+			func(is *assert.Is) { is.Equal("世界", "世人") /* not the world */ }},
+		{"normalization", fail, prefix + `differ at rune 3: 'é' (U+00E9) != 'e' (U+0065)`,
+			func(is *assert.Is) { is.Equal("caf\u00e9", "cafe\u0301") }},
+		{"shorter", fail, prefix + `differ at rune 4: <end> != '!' (U+0021)`,
+			func(is *assert.Is) { is.Equal("café", "café!") }},
+		{"longer", fail, prefix + `differ at rune 1: '界' (U+754C) != <end>`,
+			func(is *assert.Is) { is.Equal("世界", "世") }},
+		{"ascii", fail, prefix + `cafe != caff`,
+			func(is *assert.Is) { is.Equal("cafe", "caff") }},
+		{"invalid utf-8", fail, prefix + `differ at rune 0: byte 0xff != byte 0xfe`,
+			func(is *assert.Is) { is.Equal("\xff", "\xfe") }},
+		{"invalid and replacement", fail, prefix + "differ at rune 1: byte 0xff != '\ufffd' (U+FFFD)",
+			func(is *assert.Is) { is.Equal("a\xff", "a\ufffd") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+type user struct {
+	Name    string
+	Age     int
+	friends []string
+}
+
+func TestWithDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []assert.Option
+		f     func(is *assert.Is)
+		state failState
+		lines []string // lines the message must contain
+	}{
+		{"without diff", nil,
+			func(is *assert.Is) { is.Equal(user{"Alice", 17, nil}, user{"Alice", 18, nil}) }, fail,
+			[]string{"is.Equal: {Alice 17 []} != {Alice 18 []}"}},
+		{"equal", []assert.Option{assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(user{"Alice", 17, nil}, user{"Alice", 17, nil}) }, pass,
+			nil},
+		{"struct", []assert.Option{assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(user{"Alice", 17, nil}, user{"Alice", 18, nil}) /* she's an adult */ }, fail,
+			[]string{"is.Equal: values differ // she's an adult", "\t", "-", "17", "+", "18"}},
+		{"unexported field", []assert.Option{assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(&user{"Bob", 17, []string{"Alice"}}, &user{"Bob", 17, nil}) }, fail,
+			[]string{"is.Equal: values differ", "friends", "Alice"}},
+		{"map", []assert.Option{assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(map[string]int{"one": 1}, map[string]int{"one": 2}) }, fail,
+			[]string{"is.Equal: values differ", "one", "1", "2"}},
+		{"not diffable", []assert.Option{assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(1, 2) }, fail,
+			[]string{"is.Equal: 1 != 2"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, tt.opts...)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			for _, line := range tt.lines {
+				if !strings.Contains(m.msg, line) {
+					t.Errorf("%q doesn't contain %q", m.msg, line)
+				}
+			}
+		})
+	}
+}
+
+type point struct{ X, Y float64 }
+
+func TestWithCmpOptions(t *testing.T) {
+	approx := assert.WithCmpOptions(cmpopts.EquateApprox(0, 1e-9))
+	a, b := 0.1, 0.2 // variables to avoid the exact constant arithmetic
+	tests := []struct {
+		name  string
+		opts  []assert.Option
+		f     func(is *assert.Is)
+		state failState
+		lines []string // lines the message must contain
+	}{
+		{"without options", nil,
+			func(is *assert.Is) { is.Equal(a+b, 0.3) }, fail,
+			[]string{"is.Equal: 0.30000000000000004 != 0.3"}},
+		{"near-equal floats", []assert.Option{approx},
+			func(is *assert.Is) { is.Equal(a+b, 0.3) }, pass,
+			nil},
+		{"near-equal fields", []assert.Option{approx},
+			func(is *assert.Is) { is.Equal(point{a + b, 1}, point{0.3, 1}) }, pass,
+			nil},
+		{"different floats", []assert.Option{approx},
+			func(is *assert.Is) { is.Equal(0.3, 0.4) }, fail,
+			[]string{"is.Equal: 0.3 != 0.4"}},
+		{"diff with options", []assert.Option{approx, assert.WithDiff()},
+			func(is *assert.Is) { is.Equal(point{a + b, 1}, point{0.3, 2}) }, fail,
+			[]string{"is.Equal: values differ", "Y", "-", "1", "+", "2"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, tt.opts...)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			for _, line := range tt.lines {
+				if !strings.Contains(m.msg, line) {
+					t.Errorf("%q doesn't contain %q", m.msg, line)
+				}
+			}
+		})
+	}
+}
+
+func TestEqualSlice(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal([]int{1, 2, 3}, []int{1, 2, 3}) }},
+		{"equal array", pass, ``,
+			func(is *assert.Is) { is.Equal([3]string{"a"}, [3]string{"a"}) }},
+		{"index", fail, prefix + "slices differ at index 1: 2 != 9 // eyeball\n\t[1 2 3] != [1 9 3]",
+			func(is *assert.Is) { is.Equal([]int{1, 2, 3}, []int{1, 9, 3}) /* eyeball */ }},
+		{"more differences", fail, prefix + "slices differ at index 0: a != b (2 more differences)\n\t[a b c] != [b c a]",
+			func(is *assert.Is) { is.Equal([]string{"a", "b", "c"}, []string{"b", "c", "a"}) }},
+		{"array", fail, prefix + "slices differ at index 2: 0 != 1\n\t[0 0 0] != [0 0 1]",
+			func(is *assert.Is) { is.Equal([3]int{}, [3]int{2: 1}) }},
+		{"struct element", fail, prefix + "slices differ at index 0: {1 2} != {2 1}\n\t[{1 2}] != [{2 1}]",
+			func(is *assert.Is) { is.Equal([]point{{1, 2}}, []point{{2, 1}}) }},
+		{"length", fail, prefix + "len 3 != len 2\n\t[1 2 3] != [1 2]",
+			func(is *assert.Is) { is.Equal([]int{1, 2, 3}, []int{1, 2}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("testify order", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.WithTestifyOrder())
+		is.Equal([]int{1, 2}, []int{1, 3})
+		if want := prefix + "slices differ at index 1: 2 != 3\n\texpected: [1 2], actual: [1 3]"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.NilMapSliceEqual())
+		is.Equal([][]int{nil, {1}}, [][]int{{}, {2}})
+		if want := prefix + "slices differ at index 1: [1] != [2]\n\t[[] [1]] != [[] [2]]"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+
+		a, b := make([][]int, 20), make([][]int, 20)
+		for i := range b {
+			b[i] = []int{}
+		}
+		a[10], b[10] = []int{1}, []int{2}
+		is.Equal(a, b)
+		if want := prefix + "slices share first 10 and last 9 elements; differ in middle: index 10: [1] != [2]"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("comparers", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.Equal([]Money{{1, "USD"}}, []Money{{1, "usd"}})
+		if want := prefix + "slices differ at index 0: {1 USD} != {1 usd}\n\t[{1 USD}] != [{1 usd}]"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+
+		is = assert.New(m, assert.WithComparer(func(a, b slow) bool {
+			time.Sleep(100 * time.Millisecond)
+			return a == b
+		}), assert.WithCompareTimeout(10*time.Millisecond))
+		is.Equal([]slow{1, 2}, []slow{1, 3})
+		if want := prefix + "slices differ at index 1: 2 != 3\n\t[1 2] != [1 3]"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestEqualMap(t *testing.T) {
+	prefix := "is.Equal: "
+	many := func(n, offset int) map[int]int {
+		m := make(map[int]int, n)
+		for i := 0; i < n; i++ {
+			m[i] = i + offset
+		}
+		return m
+	}
+
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}) }},
+		{"value", fail, prefix + `map differs at key "b": 2 != 3 // changed`,
+			func(is *assert.Is) {
+				is.Equal(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3}) /* changed */
+			}},
+		{"value and missing key", fail, prefix + `map differs at key "b": 2 != 3; missing key "c"`,
+			func(is *assert.Is) { is.Equal(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3, "c": 4}) }},
+		{"missing key", fail, prefix + `map differs: missing key "b"`,
+			func(is *assert.Is) { is.Equal(map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}) }},
+		{"extra key", fail, prefix + `map differs: extra key 2`,
+			func(is *assert.Is) { is.Equal(map[int]string{1: "a", 2: "b"}, map[int]string{1: "a"}) }},
+		{"sorted keys", fail, prefix + `map differs at key "a": 1 != 0; at key "b": 2 != 0; missing key "c"; extra key "d"`,
+			func(is *assert.Is) {
+				is.Equal(map[string]int{"d": 4, "b": 2, "a": 1}, map[string]int{"c": 3, "b": 0, "a": 0})
+			}},
+		{"nested value", fail, prefix + `map differs at key "me": [flowers] != [flowers chocolate]`,
+			func(is *assert.Is) {
+				is.Equal(map[string][]string{"me": {"flowers"}}, map[string][]string{"me": {"flowers", "chocolate"}})
+			}},
+		{"nil map", fail, prefix + `map differs: missing key "a"`,
+			func(is *assert.Is) { is.Equal(map[string]int(nil), map[string]int{"a": 1}) }},
+		{"many differences", fail, prefix + `map differs at key 0: 0 != 1; at key 1: 1 != 2; at key 2: 2 != 3; ` +
+			`at key 3: 3 != 4; at key 4: 4 != 5; at key 5: 5 != 6; at key 6: 6 != 7; at key 7: 7 != 8; ` +
+			`at key 8: 8 != 9; at key 9: 9 != 10 (2 more differences)`,
+			func(is *assert.Is) { is.Equal(many(12, 0), many(12, 1)) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.NilMapSliceEqual())
+		is.Equal(map[string][]int{"a": nil, "b": {1}}, map[string][]int{"a": {}, "b": {2}})
+		if want := prefix + `map differs at key "b": [1] != [2]`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("comparers", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.Equal(map[string]Money{"a": {1, "USD"}}, map[string]Money{"a": {1, "usd"}})
+		if want := prefix + `map differs at key "a": {1 USD} != {1 usd}`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+
+		is = assert.New(m, assert.WithComparer(func(a, b slow) bool {
+			time.Sleep(100 * time.Millisecond)
+			return a == b
+		}), assert.WithCompareTimeout(10*time.Millisecond))
+		is.Equal(map[string]slow{"a": 1, "b": 2}, map[string]slow{"a": 1, "b": 3})
+		if want := prefix + `map differs at key "b": 2 != 3`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		want string
+	}{
+		{"equal", point{1, 2}, point{1, 2}, ``},
+		{"both nil", nil, nil, ``},
+		{"equal map", map[string]int{"a": 1}, map[string]int{"a": 1}, ``},
+		{"struct", point{1, 2}, point{2, 1}, `{1 2} != {2 1}`},
+		{"slice", []string{"flowers", "socks"}, []string{"flowers", "ring"},
+			"slices differ at index 1: socks != ring\n\t[flowers socks] != [flowers ring]"},
+		{"map", map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3, "c": 4},
+			`map differs at key "b": 2 != 3; missing key "c"`},
+		{"invalid utf-8", "\xff", "\xfe", `differ at rune 0: byte 0xff != byte 0xfe`},
+		{"different data type", 1, "1", `int(1) != string(1)`},
+		{"with nil", nil, 1, `<nil> != int(1)`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := assert.Diff(tt.a, tt.b); got != tt.want {
+				t.Errorf("%q != %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("same as Equal", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		a, b := []int{1, 2, 3}, []int{1, 9, 3}
+		is.Equal(a, b)
+		if want := "is.Equal: " + assert.Diff(a, b); m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestEqualLongSlice(t *testing.T) {
+	prefix := "is.Equal: "
+	seq := func(n int) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}
+	changed := func(s []int, indexes ...int) []int {
+		c := append([]int(nil), s...)
+		for _, i := range indexes {
+			c[i] = -1
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(seq(200), seq(200)) }},
+		{"differ in middle", fail, prefix + `slices share first 100 and last 50 elements; differ in middle: index 100: 100 != -1 (3 more differences)`,
+			func(is *assert.Is) { is.Equal(seq(154), changed(seq(154), 100, 101, 102, 103)) }},
+		{"one difference", fail, prefix + `slices share first 10 and last 19 elements; differ in middle: index 10: 10 != -1 // ten`,
+			func(is *assert.Is) { is.Equal(seq(30), changed(seq(30), 10)) /* ten */ }},
+		{"longer", fail, prefix + `slices share first 30 and last 0 elements; differ in middle: index 30: <end> != 30 (1 more difference)`,
+			func(is *assert.Is) { is.Equal(seq(30), seq(32)) }},
+		{"array", fail, prefix + `slices share first 0 and last 24 elements; differ in middle: index 0: 0 != 1`,
+			func(is *assert.Is) { is.Equal([25]int{}, [25]int{1}) }},
+		{"short", fail, prefix + "slices differ at index 1: 1 != -1\n\t[0 1 2] != [0 -1 2]",
+			func(is *assert.Is) { is.Equal(seq(3), changed(seq(3), 1)) }},
+		{"nothing in common", fail, prefix + `slices differ at index 0: 0 != -1 (19 more differences)`,
+			func(is *assert.Is) { is.Equal(make([]int, 20), changed(make([]int, 20), seq(20)...)) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
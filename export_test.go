@@ -0,0 +1,31 @@
+package is
+
+// LoadedArgument returns the loaded argument of the first assertion in file at line.
+func LoadedArgument(file string, line int) string {
+	if args := loadSource(file).arguments[line]; len(args) > 0 {
+		return args[0].expr
+	}
+	return ""
+}
+
+// LoadedComment returns the loaded comment in file at line.
+func LoadedComment(file string, line int) string {
+	return loadSource(file).comments[line]
+}
+
+// ResetSources forgets the parsed files so they are parsed again.
+func ResetSources() {
+	sources.Lock()
+	defer sources.Unlock()
+	sources.files = make(map[string]*source)
+}
+
+// LoadSourceFrom parses src as the content of file, replacing the parsed file if any.
+func LoadSourceFrom(file string, src []byte) {
+	s := new(source)
+	s.once.Do(func() { s.parse(file, src) })
+
+	sources.Lock()
+	defer sources.Unlock()
+	sources.files[file] = s
+}
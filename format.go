@@ -0,0 +1,409 @@
+package is
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var flags = struct {
+	sync.RWMutex
+	decomposers map[reflect.Type]func(v interface{}) []string
+}{decomposers: make(map[reflect.Type]func(v interface{}) []string)}
+
+/*
+RegisterFlags registers decompose as the decomposer of the bitflag type of v.
+Upon failing the test, is.Equal renders the values of that type
+by the names of their set bits that decompose returns.
+
+		type Perm uint8
+
+		const (
+			READ Perm = 1 << iota
+			WRITE
+		)
+
+		func init() {
+			is.RegisterFlags(Perm(0), func(v interface{}) []string {
+				var names []string
+				if v.(Perm)&READ != 0 {
+					names = append(names, "READ")
+				}
+				if v.(Perm)&WRITE != 0 {
+					names = append(names, "WRITE")
+				}
+				return names
+			})
+		}
+
+Will output:
+
+		is.Equal: Perm(READ|WRITE) != Perm(READ)
+*/
+func RegisterFlags(v interface{}, decompose func(v interface{}) []string) {
+	flags.Lock()
+	defer flags.Unlock()
+	flags.decomposers[reflect.TypeOf(v)] = decompose
+}
+
+// flagNames renders v by its set bits if its type is registered by RegisterFlags.
+func flagNames(v interface{}) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	typ := reflect.TypeOf(v)
+	flags.RLock()
+	decompose, ok := flags.decomposers[typ]
+	flags.RUnlock()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s(%s)", typ.Name(), strings.Join(decompose(v), "|")), true
+}
+
+var unwrappers = struct {
+	sync.RWMutex
+	unwrap map[reflect.Type]func(v interface{}) (typeName string, inner interface{})
+}{unwrap: make(map[reflect.Type]func(v interface{}) (string, interface{}))}
+
+/*
+RegisterUnwrapper registers unwrap as the unwrapper of the boxed type typ,
+such as protobuf Any or a tagged union. is.Equal compares the values of that type
+by the inner type name and the inner value that unwrap returns.
+
+		func init() {
+			is.RegisterUnwrapper(reflect.TypeOf(Any{}), func(v interface{}) (string, interface{}) {
+				a := v.(Any)
+				return a.TypeName, a.Value
+			})
+		}
+
+Will output:
+
+		is.Equal: Any<Foo>({1}) != Any<Bar>({1})
+*/
+func RegisterUnwrapper(typ reflect.Type, unwrap func(v interface{}) (typeName string, inner interface{})) {
+	unwrappers.Lock()
+	defer unwrappers.Unlock()
+	unwrappers.unwrap[typ] = unwrap
+}
+
+// boxed is the inner type name and value of a value whose type is registered by RegisterUnwrapper.
+type boxed struct {
+	box      string
+	typeName string
+	inner    interface{}
+}
+
+// unbox unwraps v if its type is registered by RegisterUnwrapper.
+func unbox(v interface{}) (boxed, bool) {
+	if v == nil {
+		return boxed{}, false
+	}
+
+	typ := reflect.TypeOf(v)
+	unwrappers.RLock()
+	unwrap, ok := unwrappers.unwrap[typ]
+	unwrappers.RUnlock()
+	if !ok {
+		return boxed{}, false
+	}
+	name, inner := unwrap(v)
+	return boxed{typ.Name(), name, inner}, true
+}
+
+// formatBoxed renders the boxed value as Box<TypeName>(inner).
+func (c *config) formatBoxed(b boxed) string {
+	return fmt.Sprintf("%s<%s>(%s)", b.box, b.typeName, c.format(b.inner))
+}
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// format renders v the way is.Equal prints its operands.
+func (c *config) format(v interface{}) string {
+	s := c.render(v)
+	if c.stable {
+		s = maskAddresses(s, v)
+		s = truncate(s, stableMaxLength)
+	}
+	return s
+}
+
+func (c *config) render(v interface{}) string {
+	if s, ok := flagNames(v); ok {
+		return s
+	}
+	if c.thousands {
+		if s, ok := groupThousands(v); ok {
+			return s
+		}
+	}
+	return fmt.Sprintf("%v", addressable(v))
+}
+
+// stableMaxLength is the maximum length of the rendered operand in the stable output.
+const stableMaxLength = 1000
+
+// maskAddresses replaces the memory addresses of the pointers, channels and functions within v
+// that fmt prints in s by 0x?, the rest of s such as the contents of the strings is left as is.
+func maskAddresses(s string, v interface{}) string {
+	seen := make(map[uintptr]bool)
+	addresses(reflect.ValueOf(v), seen)
+	if len(seen) == 0 {
+		return s
+	}
+
+	olds := make([]string, 0, len(seen))
+	for p := range seen {
+		olds = append(olds, fmt.Sprintf("%#x", p))
+	}
+	// the longest first, so an address isn't replaced by the prefix of another
+	sort.Slice(olds, func(i, j int) bool {
+		if len(olds[i]) != len(olds[j]) {
+			return len(olds[i]) > len(olds[j])
+		}
+		return olds[i] < olds[j]
+	})
+	pairs := make([]string, 0, 2*len(olds))
+	for _, old := range olds {
+		pairs = append(pairs, old, "0x?")
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+// addresses collects the memory addresses of the pointers, channels and functions within v into seen.
+func addresses(v reflect.Value, seen map[uintptr]bool) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+		addresses(v.Elem(), seen)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if p := v.Pointer(); p != 0 {
+			seen[p] = true
+		}
+	case reflect.Interface:
+		addresses(v.Elem(), seen)
+	case reflect.Array, reflect.Slice:
+		if !hasPointer(v.Type().Elem(), make(map[reflect.Type]bool)) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			addresses(v.Index(i), seen)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			addresses(iter.Key(), seen)
+			addresses(iter.Value(), seen)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			addresses(v.Field(i), seen)
+		}
+	}
+}
+
+// hasPointer reports whether the values of typ may print a memory address.
+func hasPointer(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if typ == nil || seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Interface:
+		return true
+	case reflect.Array, reflect.Slice:
+		return hasPointer(typ.Elem(), seen)
+	case reflect.Map:
+		return hasPointer(typ.Key(), seen) || hasPointer(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if hasPointer(typ.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncate cuts s to at most n bytes without splitting a rune,
+// followed by the number of the cut bytes.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", s[:n], len(s)-n)
+}
+
+// pointer renders the address of the pointer v, masked in the stable output.
+func (c *config) pointer(v interface{}) string {
+	if c.stable {
+		return "0x?"
+	}
+	return fmt.Sprintf("%p", v)
+}
+
+// groupThousands renders v with a comma every three digits if v is an integer.
+func groupThousands(v interface{}) (string, bool) {
+	var digits string
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		digits = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		digits = strconv.FormatUint(rv.Uint(), 10)
+	default:
+		return "", false
+	}
+
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String(), true
+}
+
+// mismatch renders the mismatched operands a and b of is.Equal,
+// labeled as expected and actual with the testify order.
+func (c *config) mismatch(a, b string) string {
+	if c.testifyOrder {
+		return fmt.Sprintf("expected: %s, actual: %s", a, b)
+	}
+	return fmt.Sprintf("%s != %s", a, b)
+}
+
+// percentDiff renders the relative difference of got to want, e.g. 5% higher,
+// if both are numbers and want isn't zero.
+func percentDiff(got, want interface{}) (string, bool) {
+	g, ok := toFloat(got)
+	if !ok {
+		return "", false
+	}
+	w, ok := toFloat(want)
+	if !ok || w == 0 {
+		return "", false
+	}
+
+	p := (g - w) / w * 100
+	direction := "higher"
+	if p < 0 {
+		p, direction = -p, "lower"
+	}
+	p = math.Round(p*100) / 100
+	return fmt.Sprintf("%s%% %s", strconv.FormatFloat(p, 'f', -1, 64), direction), true
+}
+
+// sameNumber reports whether a and b are numbers of the same value regardless of their types.
+// The integers are compared exactly, also against the integral floats,
+// the floats are compared as float64.
+func sameNumber(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch ka, kb := numberKind(va), numberKind(vb); {
+	case ka == 0 || kb == 0:
+		return false
+	case ka == reflect.Float64 && kb == reflect.Float64:
+		return va.Float() == vb.Float()
+	case ka == reflect.Float64:
+		return sameInteger(va.Float(), vb, kb)
+	case kb == reflect.Float64:
+		return sameInteger(vb.Float(), va, ka)
+	case ka == kb && ka == reflect.Int64:
+		return va.Int() == vb.Int()
+	case ka == kb:
+		return va.Uint() == vb.Uint()
+	case ka == reflect.Int64:
+		return va.Int() >= 0 && uint64(va.Int()) == vb.Uint()
+	default:
+		return vb.Int() >= 0 && uint64(vb.Int()) == va.Uint()
+	}
+}
+
+// sameInteger reports whether the float f is the integer v of the kind given by numberKind,
+// f is converted only if it's integral and within the range of the kind so no precision is lost.
+func sameInteger(f float64, v reflect.Value, kind reflect.Kind) bool {
+	if f != math.Trunc(f) {
+		return false
+	}
+	if kind == reflect.Int64 {
+		return f >= -(1<<63) && f < 1<<63 && int64(f) == v.Int()
+	}
+	return f >= 0 && f < 1<<64 && uint64(f) == v.Uint()
+}
+
+// numberKind classifies v as a signed integer by reflect.Int64, an unsigned integer by reflect.Uint64,
+// a float by reflect.Float64, or zero if v is not a number.
+func numberKind(v reflect.Value) reflect.Kind {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return 0
+}
+
+// toFloat converts v to float64 if v is a number.
+func toFloat(v interface{}) (float64, bool) {
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// addressable returns the pointer to a copy of v if only the pointer
+// implements fmt.Stringer or error, so fmt uses their method to print v.
+// Otherwise it returns v as is.
+func addressable(v interface{}) interface{} {
+	if v == nil {
+		return v
+	}
+
+	typ := reflect.TypeOf(v)
+	if typ.Implements(stringerType) || typ.Implements(errorType) {
+		return v
+	}
+
+	ptr := reflect.PtrTo(typ)
+	if !ptr.Implements(stringerType) && !ptr.Implements(errorType) {
+		return v
+	}
+
+	p := reflect.New(typ)
+	p.Elem().Set(reflect.ValueOf(v))
+	return p.Interface()
+}
@@ -0,0 +1,239 @@
+package is_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	assert "github.com/billyzaelani/is"
+)
+
+type Perm uint8
+
+const (
+	READ Perm = 1 << iota
+	WRITE
+	EXEC
+)
+
+func init() {
+	assert.RegisterFlags(Perm(0), func(v interface{}) []string {
+		var names []string
+		for i, name := range []string{"READ", "WRITE", "EXEC"} {
+			if v.(Perm)&(1<<i) != 0 {
+				names = append(names, name)
+			}
+		}
+		return names
+	})
+}
+
+func TestRegisterFlags(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal flags", pass, ``,
+			func(is *assert.Is) { is.Equal(READ|WRITE, READ|WRITE) }},
+		{"different flags", fail, prefix + `Perm(READ|WRITE) != Perm(READ)`,
+			func(is *assert.Is) { is.Equal(READ|WRITE, READ) }},
+		{"no flags", fail, prefix + `Perm() != Perm(EXEC)`,
+			func(is *assert.Is) { is.Equal(Perm(0), EXEC) }},
+		{"different data type", fail, prefix + `Perm(READ) != int(1)`,
+			func(is *assert.Is) { is.Equal(READ, 1) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+// Any boxes a value of any type, like protobuf Any.
+type Any struct {
+	TypeName string
+	Value    interface{}
+	size     int // cached, not part of the value
+}
+
+type Foo struct{ N int }
+
+type Bar struct{ N int }
+
+func init() {
+	assert.RegisterUnwrapper(reflect.TypeOf(Any{}), func(v interface{}) (string, interface{}) {
+		a := v.(Any)
+		return a.TypeName, a.Value
+	})
+}
+
+func TestRegisterUnwrapper(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(Any{"Foo", Foo{1}, 0}, Any{"Foo", Foo{1}, 0}) }},
+		{"equal inner value", pass, ``,
+			func(is *assert.Is) { is.Equal(Any{"Foo", Foo{1}, 0}, Any{"Foo", Foo{1}, 8}) }},
+		{"different inner type", fail, prefix + `Any<Foo>({1}) != Any<Bar>({1})`,
+			func(is *assert.Is) { is.Equal(Any{"Foo", Foo{1}, 0}, Any{"Bar", Bar{1}, 0}) }},
+		{"different inner value", fail, prefix + `Any<Foo>({1}) != Any<Foo>({2})`,
+			func(is *assert.Is) { is.Equal(Any{"Foo", Foo{1}, 0}, Any{"Foo", Foo{2}, 0}) }},
+		{"different data type", fail, prefix + `is_test.Any({Foo {1} 0}) != is_test.Foo({1})`,
+			func(is *assert.Is) { is.Equal(Any{"Foo", Foo{1}, 0}, Foo{1}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+type ptrStringer struct{ name string }
+
+func (p *ptrStringer) String() string { return "stringer " + p.name }
+
+type ptrError struct{ code int }
+
+func (p *ptrError) Error() string { return fmt.Sprintf("error %d", p.code) }
+
+func TestFormatPointerReceiver(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"stringer value", fail, prefix + `stringer foo != stringer bar`,
+			func(is *assert.Is) { is.Equal(ptrStringer{"foo"}, ptrStringer{"bar"}) }},
+		{"stringer pointer", fail, prefix + `stringer foo != stringer bar`,
+			func(is *assert.Is) { is.Equal(&ptrStringer{"foo"}, &ptrStringer{"bar"}) }},
+		{"stringer with different data type", fail, prefix + `is_test.ptrStringer(stringer foo) != string(foo)`,
+			func(is *assert.Is) { is.Equal(ptrStringer{"foo"}, "foo") }},
+		{"error value", fail, prefix + `error 1 != error 2`,
+			func(is *assert.Is) { is.Equal(ptrError{1}, ptrError{2}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestWithThousandsSeparator(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(1000000, 1000000) }},
+		{"large numbers", fail, prefix + `1,000,000 != 1,000,001`,
+			func(is *assert.Is) { is.Equal(1000000, 1000001) }},
+		{"negative numbers", fail, prefix + `-1,234,567 != -123,456`,
+			func(is *assert.Is) { is.Equal(-1234567, -123456) }},
+		{"small numbers", fail, prefix + `100 != -100`,
+			func(is *assert.Is) { is.Equal(100, -100) }},
+		{"unsigned integer", fail, prefix + `18,446,744,073,709,551,615 != 0`,
+			func(is *assert.Is) { is.Equal(^uint64(0), uint64(0)) }},
+		{"different data type", fail, prefix + `int32(1,000) != int64(1,000)`,
+			func(is *assert.Is) { is.Equal(int32(1000), int64(1000)) }},
+		{"float", fail, prefix + `1000.5 != 1000`,
+			func(is *assert.Is) { is.Equal(1000.5, 1000.0) }},
+		{"flags", fail, prefix + `Perm(READ|WRITE) != Perm(READ)`,
+			func(is *assert.Is) { is.Equal(READ|WRITE, READ) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.WithThousandsSeparator())
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestWithPercentDiff(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(100, 100) }},
+		{"higher", fail, prefix + `105 != 100 (5% higher)`,
+			func(is *assert.Is) { is.Equal(105, 100) }},
+		{"lower", fail, prefix + `2 != 3 (33.33% lower)`,
+			func(is *assert.Is) { is.Equal(uint(2), uint(3)) }},
+		{"float", fail, prefix + `1.5 != 1 (50% higher)`,
+			func(is *assert.Is) { is.Equal(1.5, 1.0) }},
+		{"zero", fail, prefix + `1 != 0`,
+			func(is *assert.Is) { is.Equal(1, 0) }},
+		{"not a number", fail, prefix + `a != b`,
+			func(is *assert.Is) { is.Equal("a", "b") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.WithPercentDiff())
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,464 @@
+package is
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+/*
+Equal asserts that a and b are equal by ==, both must be of the same comparable type
+so the mismatched operands are caught at compile time, unlike is.Equal.
+Use is.Equal for the deep comparison and the operands of different types.
+Upon failing the test, Equal reports both values with their data type.
+The interface types satisfy comparable too, but their dynamic types may not,
+then Equal fails the test naming the uncomparable type instead of panicking.
+
+The package is has to be imported by another name to call the generic helpers,
+since the test helper is usually named is.
+
+		import assert "github.com/billyzaelani/is"
+
+		func TestEqual(t *testing.T) {
+			is := assert.New(t)
+			assert.Equal(is, myAge(), 17) // she likes younger
+		}
+
+Will output:
+
+		is.Equal: int(18) != int(17) // she likes younger
+*/
+func Equal[T comparable](is *Is, a, b T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Equal"
+	skip := 3
+
+	eq, err := compare(a, b)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "%v", err)
+		return false
+	}
+	if !eq {
+		is.logf(is.Fail, skip, prefix, "%s", is.mismatch(is.valWithType(a), is.valWithType(b)))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Contains asserts that slice contains element by ==.
+
+		func TestContains(t *testing.T) {
+			is := assert.New(t)
+			assert.Contains(is, herFavorites(), "me") // please
+		}
+
+Will output:
+
+		is.Contains: [cat chocolate] does not contain me // please
+*/
+func Contains[T comparable](is *Is, slice []T, element T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Contains"
+	skip := 3
+
+	for _, v := range slice {
+		if v == element {
+			is.pass(skip, prefix)
+			return true
+		}
+	}
+
+	is.logf(is.Fail, skip, prefix, "%s does not contain %s", is.format(slice), is.format(element))
+	return false
+}
+
+/*
+Must returns v if err is nil, otherwise it fails the test by t.FailNow with err,
+it shortens the checks of the (value, error) pairs returned by a function.
+Go doesn't pass the multiple return values along with the other arguments,
+so the pair has to be assigned first.
+
+		func TestMust(t *testing.T) {
+			is := assert.New(t)
+			n, err := strconv.Atoi(herNumber())
+			phone := assert.Must(is, n, err) // she gave a fake number
+			call(phone)
+		}
+
+Will output:
+
+		is.Must: strconv.Atoi: parsing "nope": invalid syntax // she gave a fake number
+*/
+func Must[T any](is *Is, v T, err error) T {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Must"
+	skip := 3
+
+	if err != nil {
+		is.logf(is.failNow, skip, prefix, "%s", err.Error())
+		return v
+	}
+
+	is.pass(skip, prefix)
+	return v
+}
+
+// Ordered is the constraint of the types ordered by the operators <, <=, >= and >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+/*
+Greater asserts that a is greater than b.
+
+		func TestGreater(t *testing.T) {
+			is := assert.New(t)
+			assert.Greater(is, myHeight(), herHeight()) // she wears heels
+		}
+
+Will output:
+
+		is.Greater: 165 is not greater than 170 // she wears heels
+*/
+func Greater[T Ordered](is *Is, a, b T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Greater"
+	skip := 3
+
+	if !(a > b) {
+		is.logf(is.Fail, skip, prefix, "%s is not greater than %s", is.format(a), is.format(b))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+GreaterOrEqual asserts that a is greater than or equal to b.
+
+		func TestGreaterOrEqual(t *testing.T) {
+			is := assert.New(t)
+			assert.GreaterOrEqual(is, herAge(), 18) // legal age
+		}
+
+Will output:
+
+		is.GreaterOrEqual: 17 is less than 18 // legal age
+*/
+func GreaterOrEqual[T Ordered](is *Is, a, b T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.GreaterOrEqual"
+	skip := 3
+
+	if !(a >= b) {
+		is.logf(is.Fail, skip, prefix, "%s is less than %s", is.format(a), is.format(b))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Less asserts that a is less than b.
+
+		func TestLess(t *testing.T) {
+			is := assert.New(t)
+			assert.Less(is, dinnerBill(), myBudget()) // don't embarrass me
+		}
+
+Will output:
+
+		is.Less: 120 is not less than 100 // don't embarrass me
+*/
+func Less[T Ordered](is *Is, a, b T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Less"
+	skip := 3
+
+	if !(a < b) {
+		is.logf(is.Fail, skip, prefix, "%s is not less than %s", is.format(a), is.format(b))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+LessOrEqual asserts that a is less than or equal to b.
+
+		func TestLessOrEqual(t *testing.T) {
+			is := assert.New(t)
+			assert.LessOrEqual(is, minutesLate(), 5) // she waits no longer
+		}
+
+Will output:
+
+		is.LessOrEqual: 6 is greater than 5 // she waits no longer
+*/
+func LessOrEqual[T Ordered](is *Is, a, b T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.LessOrEqual"
+	skip := 3
+
+	if !(a <= b) {
+		is.logf(is.Fail, skip, prefix, "%s is greater than %s", is.format(a), is.format(b))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Between asserts that v is within the inclusive range [lo, hi], i.e. lo <= v <= hi.
+
+		func TestBetween(t *testing.T) {
+			is := assert.New(t)
+			assert.Between(is, minutesLate(), 0, 10) // the grace period
+		}
+
+Will output:
+
+		is.Between: 12 is not in [0, 10] // the grace period
+*/
+func Between[T Ordered](is *Is, v, lo, hi T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Between"
+	skip := 3
+
+	if !(lo <= v && v <= hi) {
+		is.logf(is.Fail, skip, prefix, "%s is not in [%s, %s]", is.format(v), is.format(lo), is.format(hi))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Sorted asserts that s is sorted in the ascending order, i.e. each element is less than or equal to the next.
+Upon failing the test, Sorted reports the index of the first element out of order.
+
+		func TestSorted(t *testing.T) {
+			is := assert.New(t)
+			assert.Sorted(is, herRankings()) // where am i?
+		}
+
+Will output:
+
+		is.Sorted: not sorted at index 3: 5 > 2 // where am i?
+*/
+func Sorted[T Ordered](is *Is, s []T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Sorted"
+	skip := 3
+
+	for i := 1; i < len(s); i++ {
+		if !(s[i-1] <= s[i]) {
+			is.logf(is.Fail, skip, prefix, "not sorted at index %d: %s > %s", i, is.format(s[i-1]), is.format(s[i]))
+			return false
+		}
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+SortedFunc asserts that s is sorted by less, i.e. no element is less than the one before it.
+Use SortedFunc for the custom ordering such as the descending order or sorting by a struct field.
+
+		func TestSortedFunc(t *testing.T) {
+			is := assert.New(t)
+			assert.SortedFunc(is, herDates(), func(a, b Date) bool {
+				return a.Rating > b.Rating
+			}) // the best first
+		}
+
+Will output:
+
+		is.SortedFunc: not sorted at index 1: {Bob 6} > {Me 9} // the best first
+*/
+func SortedFunc[T any](is *Is, s []T, less func(a, b T) bool) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.SortedFunc"
+	skip := 3
+
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			is.logf(is.Fail, skip, prefix, "not sorted at index %d: %s > %s", i, is.format(s[i-1]), is.format(s[i]))
+			return false
+		}
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Unique asserts that the elements of s are distinct by ==.
+Upon failing the test, Unique reports the first duplicate value with all of its indices.
+Like Equal, Unique fails the test naming the unhashable dynamic type of an interface element.
+
+		func TestUnique(t *testing.T) {
+			is := assert.New(t)
+			assert.Unique(is, herValentines()) // one each year
+		}
+
+Will output:
+
+		is.Unique: duplicate value me at indices [1 4] // one each year
+*/
+func Unique[T comparable](is *Is, s []T) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Unique"
+	skip := 3
+
+	indices, err := index(s)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "%v", err)
+		return false
+	}
+
+	for _, v := range s {
+		if len(indices[v]) > 1 {
+			is.logf(is.Fail, skip, prefix, "duplicate value %s at indices %v", is.format(v), indices[v])
+			return false
+		}
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+// compare reports whether a == b, or the error of comparing their uncomparable dynamic types.
+func compare[T comparable](a, b T) (eq bool, err error) {
+	defer uncomparable(&err)
+	return a == b, nil
+}
+
+// index maps the elements of s to their indices,
+// or returns the error of hashing their unhashable dynamic types.
+func index[T comparable](s []T) (indices map[T][]int, err error) {
+	defer uncomparable(&err)
+	indices = make(map[T][]int, len(s))
+	for i, v := range s {
+		indices[v] = append(indices[v], i)
+	}
+	return indices, nil
+}
+
+// uncomparable recovers the runtime panic of comparing or hashing
+// an uncomparable dynamic type into err.
+func uncomparable(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(runtime.Error)
+		if !ok {
+			panic(r)
+		}
+		*err = errors.New(strings.TrimPrefix(e.Error(), "runtime error: "))
+	}
+}
+
+var equals = struct {
+	sync.RWMutex
+	eq map[reflect.Type]func(a, b interface{}) bool
+}{eq: make(map[reflect.Type]func(a, b interface{}) bool)}
+
+/*
+RegisterEqual registers eq as the comparator of the values of type T.
+is.Equal compares the values of type T by eq, it wins over the built-in special cases
+such as encoding.BinaryMarshaler and RegisterUnwrapper.
+Use WithComparer to register the comparator for a single test helper.
+
+		type Money struct {
+			Amount   int64
+			Currency string
+		}
+
+		func init() {
+			is.RegisterEqual(func(a, b Money) bool {
+				return a.Amount == b.Amount && strings.EqualFold(a.Currency, b.Currency)
+			})
+		}
+*/
+func RegisterEqual[T any](eq func(a, b T) bool) {
+	equals.Lock()
+	defer equals.Unlock()
+	equals.eq[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b interface{}) bool {
+		return eq(a.(T), b.(T))
+	}
+}
+
+// registeredEqual returns the comparator of a and b if both are of the type registered by WithComparer,
+// or by RegisterEqual otherwise.
+func (c *config) registeredEqual(a, b interface{}) (func(a, b interface{}) bool, bool) {
+	typ := reflect.TypeOf(a)
+	if typ == nil || typ != reflect.TypeOf(b) {
+		return nil, false
+	}
+
+	if eq, ok := c.comparers[typ]; ok {
+		return eq, true
+	}
+
+	equals.RLock()
+	defer equals.RUnlock()
+	eq, ok := equals.eq[typ]
+	return eq, ok
+}
@@ -0,0 +1,428 @@
+package is_test
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	assert "github.com/billyzaelani/is"
+)
+
+func TestGenericEqual(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal int", pass, ``,
+			func(is *assert.Is) { assert.Equal(is, 1, 1) }},
+		{"equal string", pass, ``,
+			func(is *assert.Is) { assert.Equal(is, "girl", "girl") }},
+		{"equal struct", pass, ``,
+			func(is *assert.Is) { assert.Equal(is, point{1, 2}, point{1, 2}) }},
+		{"not equal int", fail, prefix + `int(1) != int(2) // one is not two`,
+			func(is *assert.Is) { assert.Equal(is, 1, 2) /* one is not two */ }},
+		{"not equal string", fail, prefix + `string(foo) != string(bar)`,
+			func(is *assert.Is) { assert.Equal(is, "foo", "bar") }},
+		{"not equal struct", fail, prefix + `is_test.point({1 2}) != is_test.point({2 1})`,
+			func(is *assert.Is) { assert.Equal(is, point{1, 2}, point{2, 1}) }},
+		{"nil pointer", fail, prefix + `*is_test.QueryError(<nil>) != *is_test.QueryError(query: SELECT)`,
+			func(is *assert.Is) { assert.Equal[*QueryError](is, nil, &QueryError{"SELECT"}) }},
+		{"equal interface", pass, ``,
+			func(is *assert.Is) { assert.Equal[interface{}](is, 1, 1) }},
+		{"uncomparable", fail, prefix + `comparing uncomparable type []int`,
+			func(is *assert.Is) { assert.Equal[interface{}](is, []int{1}, []int{1}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	prefix := "is.Contains: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"int", pass, ``,
+			func(is *assert.Is) { assert.Contains(is, []int{1, 2, 3}, 2) }},
+		{"string", pass, ``,
+			func(is *assert.Is) { assert.Contains(is, []string{"cat", "me"}, "me") }},
+		{"struct", pass, ``,
+			func(is *assert.Is) { assert.Contains(is, []point{{1, 2}, {3, 4}}, point{3, 4}) }},
+		{"not contain int", fail, prefix + `[1 2 3] does not contain 4 // four`,
+			func(is *assert.Is) { assert.Contains(is, []int{1, 2, 3}, 4) /* four */ }},
+		{"not contain string", fail, prefix + `[cat chocolate] does not contain me`,
+			func(is *assert.Is) { assert.Contains(is, []string{"cat", "chocolate"}, "me") }},
+		{"not contain struct", fail, prefix + `[{1 2}] does not contain {2 1}`,
+			func(is *assert.Is) { assert.Contains(is, []point{{1, 2}}, point{2, 1}) }},
+		{"empty", fail, prefix + `[] does not contain 1`,
+			func(is *assert.Is) { assert.Contains(is, nil, 1) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestOrdered(t *testing.T) {
+	type celsius float64
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"Greater int", pass, ``,
+			func(is *assert.Is) { assert.Greater(is, 5, 3) }},
+		{"Greater float", pass, ``,
+			func(is *assert.Is) { assert.Greater(is, 0.2, 0.1) }},
+		{"Greater string", pass, ``,
+			func(is *assert.Is) { assert.Greater(is, "bob", "alice") }},
+		{"not Greater int", fail, `is.Greater: 3 is not greater than 5 // too small`,
+			func(is *assert.Is) { assert.Greater(is, 3, 5) /* too small */ }},
+		{"not Greater equal", fail, `is.Greater: 5 is not greater than 5`,
+			func(is *assert.Is) { assert.Greater(is, 5, 5) }},
+		{"GreaterOrEqual equal", pass, ``,
+			func(is *assert.Is) { assert.GreaterOrEqual(is, 18, 18) }},
+		{"not GreaterOrEqual float", fail, `is.GreaterOrEqual: 17.5 is less than 18`,
+			func(is *assert.Is) { assert.GreaterOrEqual(is, 17.5, 18) }},
+		{"Less string", pass, ``,
+			func(is *assert.Is) { assert.Less(is, "alice", "bob") }},
+		{"not Less string", fail, `is.Less: bob is not less than alice`,
+			func(is *assert.Is) { assert.Less(is, "bob", "alice") }},
+		{"not Less float", fail, `is.Less: 0.2 is not less than 0.1`,
+			func(is *assert.Is) { assert.Less(is, 0.2, 0.1) }},
+		{"LessOrEqual equal", pass, ``,
+			func(is *assert.Is) { assert.LessOrEqual(is, 5, 5) }},
+		{"not LessOrEqual int", fail, `is.LessOrEqual: 6 is greater than 5 // she waits no longer`,
+			func(is *assert.Is) { assert.LessOrEqual(is, 6, 5) /* she waits no longer */ }},
+		{"not LessOrEqual named type", fail, `is.LessOrEqual: 38.5 is greater than 37.5`,
+			func(is *assert.Is) { assert.LessOrEqual(is, celsius(38.5), 37.5) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	prefix := "is.Between: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"within", pass, ``,
+			func(is *assert.Is) { assert.Between(is, 5, 0, 10) }},
+		{"lower bound", pass, ``,
+			func(is *assert.Is) { assert.Between(is, 0, 0, 10) }},
+		{"upper bound", pass, ``,
+			func(is *assert.Is) { assert.Between(is, 10, 0, 10) }},
+		{"float", pass, ``,
+			func(is *assert.Is) { assert.Between(is, 0.5, 0, 1) }},
+		{"string", pass, ``,
+			func(is *assert.Is) { assert.Between(is, "bob", "alice", "charlie") }},
+		{"below", fail, prefix + `-1 is not in [0, 10]`,
+			func(is *assert.Is) { assert.Between(is, -1, 0, 10) }},
+		{"above", fail, prefix + `12 is not in [0, 10] // the grace period`,
+			func(is *assert.Is) { assert.Between(is, 12, 0, 10) /* the grace period */ }},
+		{"empty range", fail, prefix + `5 is not in [10, 0]`,
+			func(is *assert.Is) { assert.Between(is, 5, 10, 0) }},
+		{"NaN", fail, prefix + `NaN is not in [0, 1]`,
+			func(is *assert.Is) { assert.Between(is, math.NaN(), 0, 1) }},
+		{"NaN bound", fail, prefix + `0.5 is not in [NaN, 1]`,
+			func(is *assert.Is) { assert.Between(is, 0.5, math.NaN(), 1) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	type date struct {
+		name   string
+		rating int
+	}
+	byRating := func(a, b date) bool { return a.rating > b.rating }
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"sorted", pass, ``,
+			func(is *assert.Is) { assert.Sorted(is, []int{1, 2, 3, 5}) }},
+		{"equal elements", pass, ``,
+			func(is *assert.Is) { assert.Sorted(is, []int{1, 2, 2, 2, 3}) }},
+		{"empty", pass, ``,
+			func(is *assert.Is) { assert.Sorted[int](is, nil) }},
+		{"single", pass, ``,
+			func(is *assert.Is) { assert.Sorted(is, []string{"me"}) }},
+		{"reverse sorted", fail, `is.Sorted: not sorted at index 1: 3 > 2`,
+			func(is *assert.Is) { assert.Sorted(is, []int{3, 2, 1}) }},
+		{"not sorted", fail, `is.Sorted: not sorted at index 3: 5 > 2 // where am i?`,
+			func(is *assert.Is) { assert.Sorted(is, []int{1, 3, 5, 2, 7}) /* where am i? */ }},
+		{"not sorted string", fail, `is.Sorted: not sorted at index 2: charlie > bob`,
+			func(is *assert.Is) { assert.Sorted(is, []string{"alice", "charlie", "bob"}) }},
+		{"NaN", fail, `is.Sorted: not sorted at index 1: 3 > NaN`,
+			func(is *assert.Is) { assert.Sorted(is, []float64{3, math.NaN(), 1}) }},
+		{"leading NaN", fail, `is.Sorted: not sorted at index 1: NaN > 1`,
+			func(is *assert.Is) { assert.Sorted(is, []float64{math.NaN(), 1}) }},
+		{"SortedFunc sorted", pass, ``,
+			func(is *assert.Is) { assert.SortedFunc(is, []date{{"me", 9}, {"bob", 6}}, byRating) }},
+		{"SortedFunc equal elements", pass, ``,
+			func(is *assert.Is) { assert.SortedFunc(is, []date{{"me", 9}, {"bob", 9}}, byRating) }},
+		{"SortedFunc reverse sorted", fail, `is.SortedFunc: not sorted at index 1: {bob 6} > {me 9} // the best first`,
+			func(is *assert.Is) {
+				assert.SortedFunc(is, []date{{"bob", 6}, {"me", 9}}, byRating) /* the best first */
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	prefix := "is.Unique: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"unique", pass, ``,
+			func(is *assert.Is) { assert.Unique(is, []int{1, 7, 3, 5}) }},
+		{"empty", pass, ``,
+			func(is *assert.Is) { assert.Unique[string](is, nil) }},
+		{"unique struct", pass, ``,
+			func(is *assert.Is) { assert.Unique(is, []point{{1, 2}, {2, 1}}) }},
+		{"duplicate", fail, prefix + `duplicate value 7 at indices [1 4] // dedup`,
+			func(is *assert.Is) { assert.Unique(is, []int{1, 7, 3, 5, 7}) /* dedup */ }},
+		{"all indices", fail, prefix + `duplicate value me at indices [0 2 3]`,
+			func(is *assert.Is) { assert.Unique(is, []string{"me", "bob", "me", "me"}) }},
+		{"first duplicate", fail, prefix + `duplicate value 1 at indices [0 3]`,
+			func(is *assert.Is) { assert.Unique(is, []int{1, 2, 2, 1}) }},
+		{"interface", fail, prefix + `duplicate value 1 at indices [0 2]`,
+			func(is *assert.Is) { assert.Unique(is, []interface{}{1, "1", 1}) }},
+		{"unhashable", fail, prefix + `hash of unhashable type []int`,
+			func(is *assert.Is) { assert.Unique(is, []interface{}{1, []int{1}}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMust(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		n, err := strconv.Atoi("42")
+		if got := assert.Must(is, n, err); got != 42 {
+			t.Errorf("%d != %d", got, 42)
+		}
+		assertState(t, m.state, pass)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		n, err := strconv.Atoi("nope")
+		assert.Must(is, n, err) // fake number
+		assertState(t, m.state, failNow)
+		if want := `is.Must: strconv.Atoi: parsing "nope": invalid syntax // fake number`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+// Money equals regardless of the case of its currency.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func (m Money) MarshalBinary() ([]byte, error) { return []byte(m.Currency), nil }
+
+func init() {
+	assert.RegisterEqual(func(a, b Money) bool {
+		return a.Amount == b.Amount && strings.EqualFold(a.Currency, b.Currency)
+	})
+}
+
+func TestRegisterEqual(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(Money{100, "usd"}, Money{100, "USD"}) }},
+		{"not equal", fail, prefix + `{100 USD} != {200 USD} // doubled`,
+			func(is *assert.Is) { is.Equal(Money{100, "USD"}, Money{200, "USD"}) /* doubled */ }},
+		{"registered wins over binary marshaler", fail, prefix + `{100 USD} != {200 usd}`,
+			func(is *assert.Is) { is.Equal(Money{100, "USD"}, Money{200, "usd"}) }},
+		{"different data type", fail, prefix + `is_test.Money({100 USD}) != int(100)`,
+			func(is *assert.Is) { is.Equal(Money{100, "USD"}, 100) }},
+		{"EqualOK", pass, ``,
+			func(is *assert.Is) { is.True(is.EqualOK(Money{1, "idr"}, Money{1, "IDR"})) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestGenericHelper(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+		f    func(is *assert.Is)
+	}{
+		{"Equal", 2, func(is *assert.Is) { assert.Equal(is, 1, 2) }},
+		{"Contains", 2, func(is *assert.Is) { assert.Contains(is, nil, 1) }},
+		{"Greater", 2, func(is *assert.Is) { assert.Greater(is, 1, 2) }},
+		{"GreaterOrEqual", 2, func(is *assert.Is) { assert.GreaterOrEqual(is, 1, 2) }},
+		{"Less", 2, func(is *assert.Is) { assert.Less(is, 2, 1) }},
+		{"LessOrEqual", 2, func(is *assert.Is) { assert.LessOrEqual(is, 2, 1) }},
+		{"Between", 2, func(is *assert.Is) { assert.Between(is, 2, 0, 1) }},
+		{"Sorted", 2, func(is *assert.Is) { assert.Sorted(is, []int{2, 1}) }},
+		{"SortedFunc", 2, func(is *assert.Is) { assert.SortedFunc(is, []int{1, 2}, func(a, b int) bool { return a > b }) }},
+		{"Unique", 2, func(is *assert.Is) { assert.Unique(is, []int{1, 1}) }},
+		{"Must", 2, func(is *assert.Is) { assert.Must(is, 0, errWrong) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			if m.helperCount != tt.want {
+				t.Errorf("%d != %d", m.helperCount, tt.want)
+			}
+		})
+	}
+
+	panics := []struct {
+		name string
+		f    func()
+	}{
+		{"Equal panic", func() { assert.Equal(is, 1, 1) }},
+		{"Contains panic", func() { assert.Contains(is, nil, 1) }},
+		{"Greater panic", func() { assert.Greater(is, 1, 2) }},
+		{"GreaterOrEqual panic", func() { assert.GreaterOrEqual(is, 1, 2) }},
+		{"Less panic", func() { assert.Less(is, 1, 2) }},
+		{"LessOrEqual panic", func() { assert.LessOrEqual(is, 1, 2) }},
+		{"Between panic", func() { assert.Between(is, 1, 0, 2) }},
+		{"Sorted panic", func() { assert.Sorted(is, []int{1}) }},
+		{"SortedFunc panic", func() { assert.SortedFunc(is, []int{1}, nil) }},
+		{"Unique panic", func() { assert.Unique(is, []int{1}) }},
+		{"Must panic", func() { assert.Must(is, 0, nil) }},
+	}
+
+	for _, tt := range panics {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				errMsg := "is: T is nil"
+				if err := recover(); err != errMsg {
+					t.Errorf("%q != %q", err, errMsg)
+				}
+			}()
+
+			tt.f()
+		})
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/billyzaelani/is
 
-go 1.13
+go 1.20
+
+require github.com/google/go-cmp v0.5.9
@@ -20,6 +20,21 @@ Will output:
 
 		is.Equal: 1 != 2 // expect to be the same
 
+Return value
+
+The assertions return whether they passed, so the test can guard the follow-up checks
+or log more details on the failure.
+
+		func TestReturn(t *testing.T) {
+			is := is.New(t)
+			if is.Len(gifts, 3) { // one for each anniversary
+				is.Equal(gifts[2], "ring")
+			}
+		}
+
+The assertions that use t.FailNow upon failing the test, such as is.Error, is.NoError and is.ErrorAs,
+only return on success, unless the test helper is made with is.Relaxed.
+
 Example usage
 
 The example below shows some useful ways to use package is in your test:
@@ -32,7 +47,7 @@ The example below shows some useful ways to use package is in your test:
 			"strconv"
 			"testing"
 
-			"github.com/billyzaelani/is" // neccesary test file will loaded once import the package
+			"github.com/billyzaelani/is" // the test file is parsed upon its first failure
 		)
 
 		func TestIs(t *testing.T) {
@@ -61,34 +76,73 @@ The example below shows some useful ways to use package is in your test:
 package is
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"reflect"
-)
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-func init() {
-	loadTestFile()
-}
-
-var (
-	comments  map[string]map[int]string
-	arguments map[string]map[int]string
+	"github.com/google/go-cmp/cmp"
 )
 
 // Is is the test helper.
 type Is struct {
 	T
+	config
+
+	mu   sync.Mutex
+	last string // the last failure message
+}
+
+// config is the settings of the test helper resolved from the options.
+// The zero value is the default behavior.
+type config struct {
+	compareTimeout time.Duration
+	observer       func(AssertionEvent)
+	diff           bool
+	cmpOptions     []cmp.Option
+	relaxed        bool
+	failures       *failures
+	thousands      bool
+	percent        bool
+	stable         bool
+	looseJSON      bool
+	testifyOrder   bool
+
+	ignoreUnexported bool
+	comparers        map[reflect.Type]func(a, b interface{}) bool
+	output           io.Writer
+	namespace        string
+	jsonReport       io.Writer
+	tap              *tap
+	location         bool
+	extraSkip        int
 }
 
 // New makes a new test helper given by T. Any failures will reported onto T.
 // Most of the time T will be testing.T from the stdlib.
-func New(t T) *Is {
+// The opts configure the test helper, without opts it behaves as the default.
+func New(t T, opts ...Option) *Is {
 	is := &Is{T: t}
+	is.apply(opts)
 	return is
 }
 
+func (is *Is) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(is)
+	}
+}
+
 /*
 New creates new test helper with the new T.
-(In v1.4.1 or later, this function is no different with the New function in package level)
+The new test helper inherits the settings of is, the opts are applied on top of them.
 
 		func TestNew(t *testing.T) {
 			is := is.New(t)
@@ -101,10 +155,13 @@ New creates new test helper with the new T.
 			}
 		}
 */
-func (is *Is) New(t T) *Is {
-	return &Is{
-		T: t,
+func (is *Is) New(t T, opts ...Option) *Is {
+	n := &Is{
+		T:      t,
+		config: is.config,
 	}
+	n.apply(opts)
+	return n
 }
 
 /*
@@ -120,8 +177,55 @@ is.Equal also report the data type if a and b has different data type.
 Will output:
 
 		is.Equal: string(hello girl) != bool(false) // seduce a girl
+
+If a and b are of the same type registered by WithComparer or RegisterEqual,
+is.Equal compares them by the registered comparator before anything else.
+
+If a and b are both errors, is.Equal passes if either one wraps the other by errors.Is:
+
+		is.Equal: "wrapped: boom" is not "boom"
+
+If a and b are of the same type registered by RegisterUnwrapper,
+is.Equal compares their inner type names and values instead.
+
+If a and b are of the same type implementing encoding.BinaryMarshaler,
+is.Equal compares their marshaled bytes when they aren't deeply equal,
+so the values with different internal state but the same binary form are equal.
+It takes precedence over encoding.TextMarshaler which is.Equal doesn't consult.
+Upon failing the test, is.Equal adds their marshaled bytes if a and b render the same.
+
+If a and b are single-line strings with non-ASCII characters,
+is.Equal reports the rune index of their first difference:
+
+		is.Equal: differ at rune 3: 'é' (U+00E9) != 'e' (U+0065)
+
+If a and b are long slices or arrays, is.Equal collapses their common head and tail:
+
+		is.Equal: slices share first 100 and last 50 elements; differ in middle: index 100: 5 != 6 (3 more differences)
+
+Otherwise is.Equal reports the first differing index of the slices or arrays of the same length,
+or their lengths, followed by the whole operands if they are short:
+
+		is.Equal: slices differ at index 1: 2 != 9
+			[1 2 3] != [1 9 3]
+
+If a and b are maps, is.Equal reports the keys whose values differ,
+then the keys of b missing from a and the extra keys of a:
+
+		is.Equal: map differs at key "b": 2 != 3; missing key "c"
+
+If a and b are multi-line strings, is.Equal reports their unified diff:
+
+		is.Equal: strings differ // render the letter
+		--- a
+		+++ b
+		@@ -1,3 +1,3 @@
+		 Dear girl,
+		-I love you.
+		+I like you.
+		 Regards
 */
-func (is *Is) Equal(a, b interface{}) {
+func (is *Is) Equal(a, b interface{}) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
@@ -130,220 +234,2432 @@ func (is *Is) Equal(a, b interface{}) {
 	prefix := "is.Equal"
 	skip := 3
 
-	if reflect.DeepEqual(a, b) {
-		return
+	equal, ok := is.equal(a, b)
+	if !ok {
+		is.logf(is.Fail, skip, prefix, "comparison exceeded %s (values too large?)", is.compareTimeout)
+		return false
+	}
+
+	if equal {
+		is.pass(skip, prefix)
+		return true
 	}
 
+	is.logf(is.Fail, skip, prefix, "%s", is.difference(a, b))
+	return false
+}
+
+// difference renders the difference of a and b that is.Equal reports upon failing the test.
+func (is *Is) difference(a, b interface{}) string {
 	if isNil(a) || isNil(b) {
-		is.logf(is.T.Fail, skip, "%s: %s != %s", prefix, valWithType(a), valWithType(b))
-		return
+		return is.mismatch(is.valWithType(a), is.valWithType(b))
+	}
+
+	if _, ok := is.registeredEqual(a, b); !ok {
+		if ea, eb, ok := bothErrors(a, b); ok {
+			return fmt.Sprintf("%q is not %q", ea.Error(), eb.Error())
+		}
 	}
 
 	if reflect.ValueOf(a).Type() == reflect.ValueOf(b).Type() {
-		is.logf(is.Fail, skip, "%s: %v != %v", prefix, a, b)
-		return
+		if _, ok := is.registeredEqual(a, b); ok {
+			return is.mismatch(is.format(a), is.format(b))
+		}
+
+		if ba, ok := unbox(a); ok {
+			bb, _ := unbox(b)
+			return is.mismatch(is.formatBoxed(ba), is.formatBoxed(bb))
+		}
+
+		if da, db, ok := marshalBinary(a, b); ok {
+			fa, fb := is.format(a), is.format(b)
+			if fa != fb {
+				return is.mismatch(fa, fb)
+			}
+			return fmt.Sprintf("%s (binary %x != %x)", is.mismatch(fa, fb), da, db)
+		}
+
+		if (is.diff || is.ignoreUnexported) && isDiffable(a) {
+			if diff := cmpDiff(a, b, is.cmpOptions); diff != "" {
+				return fmt.Sprintf("values differ\n%s", diff)
+			}
+		}
+
+		if isList(a) {
+			if diff, ok := is.sliceDiff(reflect.ValueOf(a), reflect.ValueOf(b)); ok {
+				return diff
+			}
+
+			if diff, ok := is.listDiff(reflect.ValueOf(a), reflect.ValueOf(b)); ok {
+				return diff
+			}
+		}
+
+		if reflect.ValueOf(a).Kind() == reflect.Map {
+			if diff, ok := is.mapDiff(reflect.ValueOf(a), reflect.ValueOf(b)); ok {
+				return diff
+			}
+		}
+
+		if sa, ok := a.(string); ok && isMultiline(sa, b.(string)) {
+			return fmt.Sprintf("strings differ\n%s", unifiedDiff(sa, b.(string)))
+		}
+
+		if sa, ok := a.(string); ok && isUnicode(sa, b.(string)) {
+			if diff, ok := runeDiff(sa, b.(string)); ok {
+				return diff
+			}
+		}
+
+		if is.percent {
+			if p, ok := percentDiff(a, b); ok {
+				return fmt.Sprintf("%s (%s)", is.mismatch(is.format(a), is.format(b)), p)
+			}
+		}
+
+		return is.mismatch(is.format(a), is.format(b))
 	}
 
-	is.logf(is.Fail, skip, "%s: %s != %s", prefix, valWithType(a), valWithType(b))
+	if isElemOf(a, b) || isElemOf(b, a) {
+		return fmt.Sprintf("%T != %T (did you mean to index the slice?)", a, b)
+	}
+
+	if isDecodedStruct(a, b) || isDecodedStruct(b, a) {
+		return fmt.Sprintf("%T != %T (comparing a decoded map to a struct?)", a, b)
+	}
+
+	if iface, ok := sharedInterface(a, b); ok {
+		return fmt.Sprintf("%s (both implement %s)", is.mismatch(is.valWithType(a), is.valWithType(b)), iface)
+	}
+
+	return is.mismatch(is.valWithType(a), is.valWithType(b))
 }
 
 /*
-Error asserts that err is one of the expectedErrors.
-Error uses errors.Is to test the error.
-If no expectedErrors is given, any error will output passed the tests.
-Error uses t.FailNow upon failing the test.
+EqualValues asserts that a and b are equal, the numbers of different types are equal
+if they have the same value, e.g. int32(1) and int64(1).
+Other values of different types are not equal as is.Equal.
 
-		func TestError(t *testing.T) {
+		func TestEqualValues(t *testing.T) {
 			is := is.New(t)
-			_, err := findGirlfriend("Anyone?")
-			is.Error(err, errors.New("coding")) // its not easy
+			var age int32 = 17
+			is.EqualValues(age, int64(18)) // almost legal
 		}
 
 Will output:
 
-		is.Error: get a girlfriend as programmer? != coding // its not easy
+		is.EqualValues: int32(17) != int64(18) // almost legal
 */
-func (is *Is) Error(err error, expectedErrors ...error) {
+func (is *Is) EqualValues(a, b interface{}) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
 
 	is.Helper()
-	prefix := "is.Error"
+	prefix := "is.EqualValues"
 	skip := 3
 
-	if err == nil {
-		is.logf(is.FailNow, skip, "%s: <nil>", prefix)
-		return
+	if reflect.DeepEqual(a, b) || sameNumber(a, b) {
+		is.pass(skip, prefix)
+		return true
 	}
 
-	lenErr := len(expectedErrors)
+	is.logf(is.Fail, skip, prefix, "%s != %s", is.valWithType(a), is.valWithType(b))
+	return false
+}
 
-	if lenErr == 0 {
-		return
+/*
+EqualOK reports whether a and b are equal the same way as is.Equal compares them,
+without failing the test nor logging. It helps to build the higher-level assertions.
+
+		func TestEqualOK(t *testing.T) {
+			is := is.New(t)
+			if !is.EqualOK(girlfriend(), "Alice") {
+				is.Equal(girlfriend(), "Bob") // plan B
+			}
+		}
+*/
+func (is *Is) EqualOK(a, b interface{}) bool {
+	equal, ok := is.equal(a, b)
+	return equal && ok
+}
+
+/*
+NotEqual asserts that a and b are not equal, the inverse of is.Equal under the same options.
+Upon failing the test, is.NotEqual reports both values with their data type.
+
+		func TestNotEqual(t *testing.T) {
+			is := is.New(t)
+			got := countGirlfriend()
+			is.NotEqual(got, 0) // forever alone
+		}
+
+Will output:
+
+		is.NotEqual: int(0) == int(0) // forever alone
+*/
+func (is *Is) NotEqual(a, b interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
 	}
 
-	for _, expectedError := range expectedErrors {
-		if errors.Is(err, expectedError) {
-			return
+	is.Helper()
+	prefix := "is.NotEqual"
+	skip := 3
+
+	equal, ok := is.equal(a, b)
+	if !ok {
+		is.logf(is.Fail, skip, prefix, "comparison exceeded %s (values too large?)", is.compareTimeout)
+		return false
+	}
+
+	if !equal {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	is.logf(is.Fail, skip, prefix, "%s == %s", is.valWithType(a), is.valWithType(b))
+	return false
+}
+
+/*
+Same asserts that the pointers a and b point to the same object,
+unlike is.Equal which compares the values they point to.
+Same fails if either one isn't a pointer.
+
+		func TestSame(t *testing.T) {
+			is := is.New(t)
+			is.Same(myGirl(), yourGirl()) // we date the same girl?
 		}
+
+Will output:
+
+		is.Same: 0xc0000140a8 != 0xc0000140b0 // we date the same girl?
+*/
+func (is *Is) Same(a, b interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
 	}
 
-	if lenErr == 1 {
-		is.logf(is.FailNow, skip, "%s: %s != %s", prefix, err.Error(), expectedErrors[0].Error())
-		return
+	is.Helper()
+	prefix := "is.Same"
+	skip := 3
+
+	if msg, ok := is.notPointers(a, b); !ok {
+		is.logf(is.Fail, skip, prefix, "%s", msg)
+		return false
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		is.logf(is.Fail, skip, prefix, "%T != %T", a, b)
+		return false
 	}
 
-	is.logf(is.FailNow, skip, "%s: %s != one of the expected errors", prefix, err.Error())
+	if a != b {
+		is.logf(is.Fail, skip, prefix, "%s != %s", is.pointer(a), is.pointer(b))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
 }
 
 /*
-ErrorAs asserts that err as target. ErrorAs uses errors.As to test the error.
-ErrorAs uses t.FailNow upon failing the test.
+NotSame asserts that the pointers a and b don't point to the same object.
+NotSame fails if either one isn't a pointer.
 
-		func TestNoError(t *testing.T) {
+		func TestNotSame(t *testing.T) {
 			is := is.New(t)
-			err := errors.New("find a way her heart")
-			var pathError *os.PathError
-			is.ErrorAs(err, &pathError) // where should I go?
+			is.NotSame(myGirl(), yourGirl()) // don't share
 		}
 
 Will output:
 
-		is.ErrorAs: err != **os.PathError // where should I go?
+		is.NotSame: both are 0xc0000140a8 // don't share
 */
-func (is *Is) ErrorAs(err error, target interface{}) {
+func (is *Is) NotSame(a, b interface{}) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
 
 	is.Helper()
-	prefix := "is.ErrorAs"
+	prefix := "is.NotSame"
 	skip := 3
 
-	if !errors.As(err, target) {
-		is.logf(is.FailNow, skip, "%s: err != %T", prefix, target)
-		return
+	if msg, ok := is.notPointers(a, b); !ok {
+		is.logf(is.Fail, skip, prefix, "%s", msg)
+		return false
+	}
+
+	if a == b {
+		is.logf(is.Fail, skip, prefix, "both are %s", is.pointer(a))
+		return false
 	}
+
+	is.pass(skip, prefix)
+	return true
 }
 
 /*
-NoError assert that err is nil. NoError uses t.FailNow upon failing the test.
+Nil asserts that v is nil. Beside the untyped nil, the nil value
+of pointer, map, slice, channel, function and interface is nil.
 
-		func TestNoError(t *testing.T) {
+		func TestNil(t *testing.T) {
 			is := is.New(t)
-			girl, err := findGirlfriend("Anyone?")
-			is.NoError(err) // i give up
-			is.Equal(girl, nil) // it will not get executed
+			girl := findGirlfriend()
+			is.Nil(girl) // she doesn't exist
 		}
 
 Will output:
 
-		is.NoError: girlfriend not found // i give up
+		is.Nil: *main.Girl(&{Alice}) is not nil // she doesn't exist
 */
-func (is *Is) NoError(err error) {
+func (is *Is) Nil(v interface{}) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
 
 	is.Helper()
-	prefix := "is.NoError"
+	prefix := "is.Nil"
 	skip := 3
 
-	if err != nil {
-		is.logf(is.FailNow, skip, "%s: %s", prefix, err.Error())
+	if isNilValue(v) {
+		is.pass(skip, prefix)
+		return true
 	}
+
+	is.logf(is.Fail, skip, prefix, "%s is not nil", is.valWithType(v))
+	return false
 }
 
 /*
-True asserts that expression is true.
-The expression code itself will be reported if the assertion fails.
+NotNil asserts that v is not nil. It is the inverse of is.Nil.
 
-		func TestTrue(t *testing.T) {
+		func TestNotNil(t *testing.T) {
 			is := is.New(t)
-			money := openTheWallet()
-			is.True(money != 0) // money shouldn't be 0 to get a girl
+			var girl *Girl
+			is.NotNil(girl) // still waiting
 		}
 
 Will output:
 
-		is.True: money != 0 // money shouldn't be 0 to get a girl
+		is.NotNil: *main.Girl(<nil>) // still waiting
 */
-func (is *Is) True(expression bool) {
+func (is *Is) NotNil(v interface{}) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
 
 	is.Helper()
-	prefix := "is.True"
+	prefix := "is.NotNil"
 	skip := 3
 
-	if expression {
-		return
+	if !isNilValue(v) {
+		is.pass(skip, prefix)
+		return true
 	}
 
-	args := is.loadArgument()
-	is.logf(is.Fail, skip, "%s: %s", prefix, args)
+	is.logf(is.Fail, skip, prefix, "%s", is.valWithType(v))
+	return false
 }
 
 /*
-Panic assert that function f is panic.
+Len asserts that the length of v is n.
+v must be a slice, map, string, array or channel.
 
-		func TestPanic(t *testing.T) {
+		func TestLen(t *testing.T) {
 			is := is.New(t)
-			panicFunc := func() { panic("single") }
-			is.Panic(panicFunc, "really panic", "crazy panic") // ok
+			girls := findGirlfriends()
+			is.Len(girls, 5) // as many as the fingers
 		}
 
 Will output:
 
-		is.Panic: single != one of the expected panic values // ok
+		is.Len: len 3 != 5 // as many as the fingers
 */
-func (is *Is) Panic(f PanicFunc, expectedValues ...interface{}) {
+func (is *Is) Len(v interface{}, n int) bool {
 	if is.T == nil {
 		panic("is: T is nil")
 	}
 
 	is.Helper()
+	prefix := "is.Len"
+	skip := 3
 
-	defer func(expectedValues ...interface{}) {
-		is.Helper()
-		prefix := "is.Panic"
-		skip := 4
+	if !hasLen(v) {
+		is.logf(is.Fail, skip, prefix, "%s has no length", is.valWithType(v))
+		return false
+	}
 
-		r := recover()
-		if r == nil {
-			is.logf(is.Fail, skip, "%s: the function is not panic", prefix)
-			return
+	if l := reflect.ValueOf(v).Len(); l != n {
+		is.logf(is.Fail, skip, prefix, "len %d != %d", l, n)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Empty asserts that v is empty. The nil, the slice, map, channel and string of zero length,
+and the zero value of the other types are empty, i.e. 0 for the numbers, false for the bools,
+nil for the pointers, functions and interfaces, and the zero value of the structs and arrays.
+
+		func TestEmpty(t *testing.T) {
+			is := is.New(t)
+			is.Empty(herExes()) // i'm the first one
 		}
 
-		lenVal := len(expectedValues)
+Will output:
 
-		if lenVal == 0 {
-			return
+		is.Empty: [Bob Charlie] is not empty // i'm the first one
+*/
+func (is *Is) Empty(v interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Empty"
+	skip := 3
+
+	if !isEmpty(v) {
+		is.logf(is.Fail, skip, prefix, "%s is not empty", is.format(v))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NotEmpty asserts that v is not empty, the emptiness is the same as is.Empty.
+
+		func TestNotEmpty(t *testing.T) {
+			is := is.New(t)
+			is.NotEmpty(herReply()) // say something
 		}
 
-		for _, v := range expectedValues {
-			if reflect.DeepEqual(r, v) {
-				return
-			}
+Will output:
+
+		is.NotEmpty:  is empty // say something
+*/
+func (is *Is) NotEmpty(v interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NotEmpty"
+	skip := 3
+
+	if isEmpty(v) {
+		is.logf(is.Fail, skip, prefix, "%s is empty", is.format(v))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Zero asserts that v is the zero value of its type by reflect.Value.IsZero, the nil is zero.
+Unlike is.Empty, the slice, map and channel are zero only if they are nil.
+
+		func TestZero(t *testing.T) {
+			is := is.New(t)
+			is.Zero(herCalls()) // she never calls
 		}
 
-		if lenVal == 1 {
-			is.logf(is.Fail, skip, "%s: %v != %v", prefix, r, expectedValues[0])
-			return
+Will output:
+
+		is.Zero: int(5) is not the zero value // she never calls
+*/
+func (is *Is) Zero(v interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Zero"
+	skip := 3
+
+	if !isZero(v) {
+		is.logf(is.Fail, skip, prefix, "%s is not the zero value", is.valWithType(v))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NotZero asserts that v isn't the zero value of its type by reflect.Value.IsZero.
+
+		func TestNotZero(t *testing.T) {
+			is := is.New(t)
+			is.NotZero(herCalls()) // call me maybe
 		}
 
-		is.logf(is.Fail, skip, "%s: %v != one of the expected panic values", prefix, r)
-	}(expectedValues...)
+Will output:
 
-	f()
+		is.NotZero: int(0) is the zero value // call me maybe
+*/
+func (is *Is) NotZero(v interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NotZero"
+	skip := 3
+
+	if isZero(v) {
+		is.logf(is.Fail, skip, prefix, "%s is the zero value", is.valWithType(v))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+MapHasKey asserts that the map m has the key key.
+
+		func TestMapHasKey(t *testing.T) {
+			is := is.New(t)
+			is.MapHasKey(herContacts(), "me") // at least save my number
+		}
+
+Will output:
+
+		is.MapHasKey: map has no key "me" // at least save my number
+*/
+func (is *Is) MapHasKey(m, key interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.MapHasKey"
+	skip := 3
+
+	if _, ok, msg := is.mapIndex(m, key); msg != "" {
+		is.logf(is.Fail, skip, prefix, "%s", msg)
+		return false
+	} else if !ok {
+		is.logf(is.Fail, skip, prefix, "map has no key %s", is.mapKey(key))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+MapValue asserts that the map m has the key key and its value is deeply equal to want.
+Upon failing the test, MapValue reports the missing key apart from the wrong value.
+
+		func TestMapValue(t *testing.T) {
+			is := is.New(t)
+			is.MapValue(herRatings(), "me", 10) // perfect score
+		}
+
+Will output:
+
+		is.MapValue: m["me"] = 7, want 10 // perfect score
+*/
+func (is *Is) MapValue(m, key, want interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.MapValue"
+	skip := 3
+
+	got, ok, msg := is.mapIndex(m, key)
+	if msg != "" {
+		is.logf(is.Fail, skip, prefix, "%s", msg)
+		return false
+	}
+
+	if !ok {
+		is.logf(is.Fail, skip, prefix, "map has no key %s", is.mapKey(key))
+		return false
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		g, w := is.format(got), is.format(want)
+		if reflect.TypeOf(got) != reflect.TypeOf(want) {
+			g, w = is.valWithType(got), is.valWithType(want)
+		}
+		is.logf(is.Fail, skip, prefix, "m[%s] = %s, want %s", is.mapKey(key), g, w)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+ElementsMatch asserts that the slices or arrays a and b have the same elements regardless of their order,
+the number of the duplicates must match as well. Upon failing the test,
+is.ElementsMatch reports the elements of a missing from b and vice versa.
+
+		func TestElementsMatch(t *testing.T) {
+			is := is.New(t)
+			is.ElementsMatch(myFlowers(), []string{"rose", "tulip"}) // she likes them all
+		}
+
+Will output:
+
+		is.ElementsMatch: extra in a: [lily], missing from a: [tulip] // she likes them all
+*/
+func (is *Is) ElementsMatch(a, b interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.ElementsMatch"
+	skip := 3
+
+	for _, v := range []interface{}{a, b} {
+		if !isList(v) {
+			is.logf(is.Fail, skip, prefix, "%s is not a slice or an array", is.valWithType(v))
+			return false
+		}
+	}
+
+	extra, missing := diffElements(reflect.ValueOf(a), reflect.ValueOf(b))
+	if len(extra) == 0 && len(missing) == 0 {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	var diffs []string
+	if len(extra) > 0 {
+		diffs = append(diffs, "extra in a: "+is.formatList(extra))
+	}
+	if len(missing) > 0 {
+		diffs = append(diffs, "missing from a: "+is.formatList(missing))
+	}
+	is.logf(is.Fail, skip, prefix, "%s", strings.Join(diffs, ", "))
+	return false
+}
+
+/*
+Subset asserts that every element of part is an element of whole.
+The slices and arrays contain the elements, the maps contain the keys with their values.
+Upon failing the test, is.Subset reports the elements of part missing from whole.
+
+		func TestSubset(t *testing.T) {
+			is := is.New(t)
+			is.Subset(myWishlist(), []string{"flowers", "chocolate"}) // her wishes
+		}
+
+Will output:
+
+		is.Subset: [flowers teddy] is missing [chocolate] // her wishes
+*/
+func (is *Is) Subset(whole, part interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Subset"
+	skip := 3
+
+	missing, ok := is.missing(whole, part)
+	if !ok {
+		is.logf(is.Fail, skip, prefix, "%T and %T are not the same kind of collection", whole, part)
+		return false
+	}
+	if missing != "" {
+		is.logf(is.Fail, skip, prefix, "%s is missing %s", is.format(whole), missing)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Superset asserts that whole contains every element of part, the same as is.Subset
+but reads from the side of whole.
+
+		func TestSuperset(t *testing.T) {
+			is := is.New(t)
+			is.Superset(myWishlist(), []string{"flowers", "chocolate"}) // her wishes
+		}
+
+Will output:
+
+		is.Superset: [flowers teddy] is not a superset of [flowers chocolate], missing [chocolate] // her wishes
+*/
+func (is *Is) Superset(whole, part interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Superset"
+	skip := 3
+
+	missing, ok := is.missing(whole, part)
+	if !ok {
+		is.logf(is.Fail, skip, prefix, "%T and %T are not the same kind of collection", whole, part)
+		return false
+	}
+	if missing != "" {
+		is.logf(is.Fail, skip, prefix, "%s is not a superset of %s, missing %s", is.format(whole), is.format(part), missing)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+IsType asserts that actual has the same dynamic type as expected.
+
+		func TestIsType(t *testing.T) {
+			is := is.New(t)
+			gift := wrap("present")
+			is.IsType(&Flower{}, gift) // she expects flowers
+		}
+
+Will output:
+
+		is.IsType: expected *Flower, got *Chocolate // she expects flowers
+*/
+func (is *Is) IsType(expected, actual interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.IsType"
+	skip := 3
+
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		is.logf(is.Fail, skip, prefix, "expected %T, got %T", expected, actual)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+LinesElementsMatch asserts that the lines read from got are the lines of want regardless of their order,
+the number of the duplicates must match as well. The trailing newline of got doesn't make an empty line.
+Upon failing the test, is.LinesElementsMatch reports the extra and the missing lines of got.
+
+		func TestLinesElementsMatch(t *testing.T) {
+			is := is.New(t)
+			is.LinesElementsMatch(loveLetters(), []string{"I love you", "I miss you"}) // from her
+		}
+
+Will output:
+
+		is.LinesElementsMatch: extra in got: ["I hate you"], missing from got: ["I love you"] // from her
+*/
+func (is *Is) LinesElementsMatch(got io.Reader, want []string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.LinesElementsMatch"
+	skip := 3
+
+	var lines []string
+	scanner := bufio.NewScanner(got)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		is.logf(is.Fail, skip, prefix, "reading got: %s", err)
+		return false
+	}
+
+	extra, missing := diffElements(reflect.ValueOf(lines), reflect.ValueOf(want))
+	if len(extra) == 0 && len(missing) == 0 {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	var diffs []string
+	if len(extra) > 0 {
+		diffs = append(diffs, fmt.Sprintf("extra in got: %q", extra))
+	}
+	if len(missing) > 0 {
+		diffs = append(diffs, fmt.Sprintf("missing from got: %q", missing))
+	}
+	is.logf(is.Fail, skip, prefix, "%s", strings.Join(diffs, ", "))
+	return false
+}
+
+/*
+EqualGroupedUnordered asserts that got and want have the same groups in the same order,
+the order of the elements within each group doesn't matter.
+It is handy for paginated or batched results.
+
+		func TestEqualGroupedUnordered(t *testing.T) {
+			is := is.New(t)
+			pages := paginate([]int{1, 2, 3, 4}, 2)
+			is.EqualGroupedUnordered(pages, [][]int{{2, 1}, {4, 5}}) // second page
+		}
+
+Will output:
+
+		is.EqualGroupedUnordered: group 1: [3 4] != [4 5] // second page
+*/
+func (is *Is) EqualGroupedUnordered(got, want [][]int) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.EqualGroupedUnordered"
+	skip := 3
+
+	if len(got) != len(want) {
+		is.logf(is.Fail, skip, prefix, "%d groups != %d groups", len(got), len(want))
+		return false
+	}
+
+	for i := range got {
+		if !sameInts(got[i], want[i]) {
+			is.logf(is.Fail, skip, prefix, "group %d: %v != %v", i, got[i], want[i])
+			return false
+		}
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+TypeOneOf asserts that the data type of v is the data type of one of the types exemplars.
+The untyped nil as v matches only the untyped nil exemplar.
+
+		func TestTypeOneOf(t *testing.T) {
+			is := is.New(t)
+			err := dial()
+			is.TypeOneOf(err, &net.OpError{}, &net.DNSError{}) // network error
+		}
+
+Will output:
+
+		is.TypeOneOf: got *os.PathError, want one of [*net.OpError, *net.DNSError] // network error
+*/
+func (is *Is) TypeOneOf(v interface{}, types ...interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.TypeOneOf"
+	skip := 3
+
+	got := reflect.TypeOf(v)
+	want := make([]string, len(types))
+	for i, typ := range types {
+		if reflect.TypeOf(typ) == got {
+			is.pass(skip, prefix)
+			return true
+		}
+		want[i] = typeName(typ)
+	}
+
+	is.logf(is.Fail, skip, prefix, "got %s, want one of [%s]", typeName(v), strings.Join(want, ", "))
+	return false
+}
+
+/*
+GraphEqual asserts that the adjacency maps got and want have the same nodes
+with the same edges, the edges of each node are treated as an unordered set.
+Upon failing the test, is.GraphEqual reports every node with different edges.
+
+		func TestGraphEqual(t *testing.T) {
+			is := is.New(t)
+			deps := resolve("app")
+			is.GraphEqual(deps, map[string][]string{"a": {"b", "d"}}) // dependency graph
+		}
+
+Will output:
+
+		is.GraphEqual: node "a": edges [b c] != [b d] // dependency graph
+*/
+func (is *Is) GraphEqual(got, want map[string][]string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.GraphEqual"
+	skip := 3
+
+	if diffs := graphDiff(got, want); len(diffs) > 0 {
+		is.logf(is.Fail, skip, prefix, "%s", strings.Join(diffs, "; "))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Implements asserts that v implements the interface iface points to, e.g. (*io.Reader)(nil).
+Implements panics if iface is not a pointer to an interface.
+
+		func TestImplements(t *testing.T) {
+			is := is.New(t)
+			is.Implements((*Lover)(nil), me()) // am I?
+		}
+
+Will output:
+
+		is.Implements: *Nerd does not implement Lover // am I?
+*/
+func (is *Is) Implements(iface, v interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	typ := reflect.TypeOf(iface)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+		panic("is: iface must be a pointer to an interface")
+	}
+
+	is.Helper()
+	prefix := "is.Implements"
+	skip := 3
+
+	if v == nil || !reflect.TypeOf(v).Implements(typ.Elem()) {
+		is.logf(is.Fail, skip, prefix, "%T does not implement %s", v, typ.Elem())
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+EqualStripANSI asserts that a and b are equal after removing
+the ANSI escape sequences, such as the color codes, from both of them.
+The escape sequences are the control sequences matching the regexp
+
+		\x1b\[[0-?]*[ -/]*[@-~]
+
+Upon failing the test, is.EqualStripANSI reports the stripped forms.
+
+		func TestEqualStripANSI(t *testing.T) {
+			is := is.New(t)
+			got := render("\x1b[31mhello\x1b[0m")
+			is.EqualStripANSI(got, "hello world") // colored greeting
+		}
+
+Will output:
+
+		is.EqualStripANSI: hello != hello world // colored greeting
+*/
+func (is *Is) EqualStripANSI(a, b string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.EqualStripANSI"
+	skip := 3
+
+	sa, sb := stripANSI(a), stripANSI(b)
+	if sa != sb {
+		is.logf(is.Fail, skip, prefix, "%s != %s", sa, sb)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+WithinDuration asserts that a and b differ by at most delta in either direction,
+regardless of their locations and monotonic clock readings.
+
+		func TestWithinDuration(t *testing.T) {
+			is := is.New(t)
+			promised := time.Date(2023, 2, 14, 19, 0, 0, 0, time.UTC)
+			is.WithinDuration(arrival(), promised, time.Second) // don't be late
+		}
+
+Will output:
+
+		is.WithinDuration: 2023-02-14T19:00:03Z and 2023-02-14T19:00:00Z differ by 3s, allowed 1s // don't be late
+*/
+func (is *Is) WithinDuration(a, b time.Time, delta time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.WithinDuration"
+	skip := 3
+
+	if diff := absDuration(a.Sub(b)); diff > delta {
+		is.logf(is.Fail, skip, prefix, "%s and %s differ by %s, allowed %s", formatTime(&a), formatTime(&b), diff, delta)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+TimeFormatEqual asserts that got, parsed with layout, is the same instant as want.
+Upon failing the test, is.TimeFormatEqual reports both times formatted with layout,
+or in RFC 3339 if layout can't tell them apart.
+
+		func TestTimeFormatEqual(t *testing.T) {
+			is := is.New(t)
+			anniversary := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+			is.TimeFormatEqual(myCalendar(), "2006-01-02", anniversary) // don't forget
+		}
+
+Will output:
+
+		is.TimeFormatEqual: "2024-01-02" parses to 2024-01-02, want 2024-01-03 // don't forget
+*/
+func (is *Is) TimeFormatEqual(got string, layout string, want time.Time) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.TimeFormatEqual"
+	skip := 3
+
+	parsed, err := time.Parse(layout, got)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "%q doesn't parse with layout %q: %s", got, layout, err)
+		return false
+	}
+
+	if !parsed.Equal(want) {
+		sp, sw := parsed.Format(layout), want.Format(layout)
+		if sp == sw {
+			sp, sw = formatTime(&parsed), formatTime(&want)
+		}
+		is.logf(is.Fail, skip, prefix, "%q parses to %s, want %s", got, sp, sw)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NullableTimeEqual asserts that got and want are both nil,
+or both non-nil and differ by at most delta.
+
+		func TestNullableTimeEqual(t *testing.T) {
+			is := is.New(t)
+			want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			got := user.DeletedAt
+			is.NullableTimeEqual(got, &want, time.Second) // soft deleted
+		}
+
+Will output:
+
+		is.NullableTimeEqual: got <nil>, want 2024-01-02T00:00:00Z // soft deleted
+*/
+func (is *Is) NullableTimeEqual(got, want *time.Time, delta time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NullableTimeEqual"
+	skip := 3
+
+	if got == nil && want == nil {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	if got == nil || want == nil {
+		is.logf(is.Fail, skip, prefix, "got %s, want %s", formatTime(got), formatTime(want))
+		return false
+	}
+
+	if diff := absDuration(got.Sub(*want)); diff > delta {
+		is.logf(is.Fail, skip, prefix, "got %s, want %s, differ by %s, allowed %s",
+			formatTime(got), formatTime(want), diff, delta)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+MapOrderEqual asserts that the ordered map given by gotKeys and gotVals
+has the keys and values of wantPairs in the same order, each pair is a key and its value.
+Upon failing the test, is.MapOrderEqual reports the key order and value differences separately.
+
+		func TestMapOrderEqual(t *testing.T) {
+			is := is.New(t)
+			keys, vals := headers()
+			is.MapOrderEqual(keys, vals, [][2]interface{}{{"Host", "example.com"}, {"Accept", "*"}}) // headers in order
+		}
+
+Will output:
+
+		is.MapOrderEqual: key order [Accept Host] != [Host Accept] // headers in order
+*/
+func (is *Is) MapOrderEqual(gotKeys []string, gotVals map[string]interface{}, wantPairs [][2]interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.MapOrderEqual"
+	skip := 3
+
+	var diffs []string
+	wantKeys := make([]interface{}, len(wantPairs))
+	for i, pair := range wantPairs {
+		wantKeys[i] = pair[0]
+	}
+	if !reflect.DeepEqual(toInterfaces(gotKeys), wantKeys) {
+		diffs = append(diffs, fmt.Sprintf("key order %v != %v", gotKeys, wantKeys))
+	}
+
+	for _, pair := range wantPairs {
+		key, ok := pair[0].(string)
+		if !ok {
+			continue // not a key of gotVals, already reported by the key order
+		}
+		got, ok := gotVals[key]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("value of %q: missing != %s", key, is.format(pair[1])))
+		case !reflect.DeepEqual(got, pair[1]):
+			diffs = append(diffs, fmt.Sprintf("value of %q: %s != %s", key, is.format(got), is.format(pair[1])))
+		}
+	}
+
+	if len(diffs) > 0 {
+		is.logf(is.Fail, skip, prefix, "%s", strings.Join(diffs, "; "))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+EqualLazy asserts that a and b are equal after their lazy initialization,
+ignoring their sync.Once, sync.Mutex and sync.RWMutex fields.
+The lazy initialization is forced by calling the Init method of a and b, if any.
+The non-pointer values are initialized on their copies.
+
+		func TestEqualLazy(t *testing.T) {
+			is := is.New(t)
+			heart := &Heart{Owner: "me"}
+			heart.Init()
+			is.EqualLazy(&Heart{Owner: "her"}, heart) // whose heart?
+		}
+
+Will output:
+
+		is.EqualLazy: values differ // whose heart?
+			  &Heart{
+			- 	Owner: "her",
+			+ 	Owner: "me",
+			  	...
+			  }
+*/
+func (is *Is) EqualLazy(a, b interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.EqualLazy"
+	skip := 3
+
+	a, b = initialize(a), initialize(b)
+	opts := append([]cmp.Option{ignoreSync}, is.cmpOptions...)
+	if cmpEqual(a, b, opts) {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	if isNil(a) || isNil(b) || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		is.logf(is.Fail, skip, prefix, "%s != %s", is.valWithType(a), is.valWithType(b))
+		return false
+	}
+
+	is.logf(is.Fail, skip, prefix, "values differ\n%s", cmpDiff(a, b, opts))
+	return false
+}
+
+/*
+NormalizedEqual asserts that got and want are logically the same
+after normalizing both to a canonical form, for the values decoded from different formats.
+The normalization converts the integers and the integer-like floats to int64,
+the maps and the structs to maps with string keys, and treats null, nil and empty containers as equal.
+Upon failing the test, is.NormalizedEqual reports both normalized forms.
+
+		func TestNormalizedEqual(t *testing.T) {
+			is := is.New(t)
+			var fromJSON, fromYAML map[string]interface{}
+			json.Unmarshal(jsonConfig, &fromJSON) // {"age": 17, "hobbies": []}
+			yaml.Unmarshal(yamlConfig, &fromYAML) // {age: 18, hobbies: null}
+			is.NormalizedEqual(fromJSON, fromYAML) // migrated config
+		}
+
+Will output:
+
+		is.NormalizedEqual: map[age:17 hobbies:<nil>] != map[age:18 hobbies:<nil>] (normalized) // migrated config
+*/
+func (is *Is) NormalizedEqual(got, want interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NormalizedEqual"
+	skip := 3
+
+	ng, nw := normalize(reflect.ValueOf(got)), normalize(reflect.ValueOf(want))
+	if reflect.DeepEqual(ng, nw) {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	is.logf(is.Fail, skip, prefix, "%v != %v (normalized)", ng, nw)
+	return false
+}
+
+/*
+SignatureEqual asserts that the functions got and want have the same signature,
+i.e. the same parameter and result types and variadic-ness, regardless of their identity
+and the name of their types. SignatureEqual panics if got or want isn't a function.
+
+		func TestSignatureEqual(t *testing.T) {
+			is := is.New(t)
+			is.SignatureEqual(plugin.Lookup("Kiss"), func(int) error { return nil }) // compatible?
+		}
+
+Will output:
+
+		is.SignatureEqual: func(string) error != func(int) error // compatible?
+*/
+func (is *Is) SignatureEqual(got, want interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.SignatureEqual"
+	skip := 3
+
+	sg, sw := signature(got), signature(want)
+	if sg == sw {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	is.logf(is.Fail, skip, prefix, "%s != %s", sg, sw)
+	return false
+}
+
+/*
+JSONEqual asserts that a and b are semantically equal JSON documents
+regardless of their formatting or the order of the object keys.
+Upon failing the test, is.JSONEqual reports both documents in the compact form
+followed by the path of their first difference, unless they differ at the root.
+
+		func TestJSONEqual(t *testing.T) {
+			is := is.New(t)
+			profile := fetchProfile("Alice")
+			is.JSONEqual(profile, `{"name": "Alice", "single": true}`) // too good to be true
+		}
+
+Will output:
+
+		is.JSONEqual: {"name":"Alice","single":false} != {"name":"Alice","single":true} // too good to be true
+			at $.single: false != true
+*/
+func (is *Is) JSONEqual(a, b string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.JSONEqual"
+	skip := 3
+
+	va, vb, coerced, err := is.decodeDocuments(a, b)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "%s", err)
+		return false
+	}
+
+	if reflect.DeepEqual(va, vb) {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	msg := fmt.Sprintf("%s != %s%s", encodeJSON(va), encodeJSON(vb), coerced)
+	if path, diff := jsonDiff(va, vb, "$"); path != "$" {
+		msg += fmt.Sprintf("\n\tat %s: %s", path, diff)
+	}
+	is.logf(is.Fail, skip, prefix, "%s", msg)
+	return false
+}
+
+/*
+JSONEqualBytes asserts that a and b are semantically equal JSON documents the same as is.JSONEqual,
+for the documents from json.Marshal or io.ReadAll. Upon failing the test,
+JSONEqualBytes reports the path of their first difference and both documents indented.
+
+		func TestJSONEqualBytes(t *testing.T) {
+			is := is.New(t)
+			profile, _ := json.Marshal(girl{Name: "Alice", Single: false})
+			is.JSONEqualBytes(profile, []byte(`{"name": "Alice", "single": true}`)) // too good to be true
+		}
+
+Will output:
+
+		is.JSONEqualBytes: documents differ // too good to be true
+			at $.single: false != true
+			a: {
+			  "name": "Alice",
+			  "single": false
+			}
+			b: {
+			  "name": "Alice",
+			  "single": true
+			}
+*/
+func (is *Is) JSONEqualBytes(a, b []byte) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.JSONEqualBytes"
+	skip := 3
+
+	va, vb, coerced, err := is.decodeDocuments(string(a), string(b))
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "%s", err)
+		return false
+	}
+
+	if reflect.DeepEqual(va, vb) {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	msg := "documents differ" + coerced
+	if path, diff := jsonDiff(va, vb, "$"); path != "$" {
+		msg += fmt.Sprintf("\n\tat %s: %s", path, diff)
+	}
+	msg += fmt.Sprintf("\n\ta: %s\n\tb: %s", indentJSON(va), indentJSON(vb))
+	is.logf(is.Fail, skip, prefix, "%s", msg)
+	return false
+}
+
+/*
+InDelta asserts that a and b differ by at most delta, for the floats that can't be compared exactly.
+
+		func TestInDelta(t *testing.T) {
+			is := is.New(t)
+			a, b := 0.1, 0.2
+			is.InDelta(a+b, 0.3, 0) // math is hard
+		}
+
+Will output:
+
+		is.InDelta: 0.30000000000000004 and 0.3 differ by 5.551115123125783e-17, allowed 0 // math is hard
+*/
+func (is *Is) InDelta(a, b, delta float64) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.InDelta"
+	skip := 3
+
+	if math.IsNaN(a) || math.IsNaN(b) {
+		is.logf(is.Fail, skip, prefix, "NaN is not comparable")
+		return false
+	}
+
+	if diff := math.Abs(a - b); a != b && !(diff <= delta) {
+		is.logf(is.Fail, skip, prefix, "%v and %v differ by %v, allowed %v", a, b, diff, delta)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+InEpsilon asserts that the relative error of a to b, |a-b|/|b|, is at most epsilon,
+for the floats of wildly different magnitudes.
+
+		func TestInEpsilon(t *testing.T) {
+			is := is.New(t)
+			is.InEpsilon(lightYears(), 9.46e15, 0.01) // so far away
+		}
+
+Will output:
+
+		is.InEpsilon: 9.6e+15 and 9.46e+15 differ by relative error 0.014799154334038054, allowed 0.01 // so far away
+*/
+func (is *Is) InEpsilon(a, b, epsilon float64) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.InEpsilon"
+	skip := 3
+
+	if math.IsNaN(a) || math.IsNaN(b) {
+		is.logf(is.Fail, skip, prefix, "NaN is not comparable")
+		return false
+	}
+
+	if a == b {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	if b == 0 {
+		is.logf(is.Fail, skip, prefix, "relative error of %v to 0 is undefined", a)
+		return false
+	}
+
+	if rel := math.Abs((a - b) / b); !(rel <= epsilon) {
+		is.logf(is.Fail, skip, prefix, "%v and %v differ by relative error %v, allowed %v", a, b, rel, epsilon)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Match asserts that s matches the regular expression pattern.
+Match fails the test, instead of panics, if pattern is invalid.
+
+		func TestMatch(t *testing.T) {
+			is := is.New(t)
+			reply := textHer("hello")
+			is.Match(reply, "^hello") // she replied
+		}
+
+Will output:
+
+		is.Match: "k" does not match "^hello" // she replied
+*/
+func (is *Is) Match(s, pattern string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Match"
+	skip := 3
+
+	matched, err := regexp.MatchString(pattern, s)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "invalid pattern %q: %s", pattern, err)
+		return false
+	}
+
+	if !matched {
+		is.logf(is.Fail, skip, prefix, "%q does not match %q", s, pattern)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NotMatch asserts that s doesn't match the regular expression pattern.
+NotMatch fails the test, instead of panics, if pattern is invalid.
+
+		func TestNotMatch(t *testing.T) {
+			is := is.New(t)
+			reply := textHer("hello")
+			is.NotMatch(reply, "^k$") // not that reply
+		}
+
+Will output:
+
+		is.NotMatch: "k" matches "^k$" // not that reply
+*/
+func (is *Is) NotMatch(s, pattern string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NotMatch"
+	skip := 3
+
+	matched, err := regexp.MatchString(pattern, s)
+	if err != nil {
+		is.logf(is.Fail, skip, prefix, "invalid pattern %q: %s", pattern, err)
+		return false
+	}
+
+	if matched {
+		is.logf(is.Fail, skip, prefix, "%q matches %q", s, pattern)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+HasPrefix asserts that s starts with pre.
+
+		func TestHasPrefix(t *testing.T) {
+			is := is.New(t)
+			is.HasPrefix(herReply(), "yes") // fingers crossed
+		}
+
+Will output:
+
+		is.HasPrefix: "no, thanks" does not start with "yes" // fingers crossed
+*/
+func (is *Is) HasPrefix(s, pre string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.HasPrefix"
+	skip := 3
+
+	if !strings.HasPrefix(s, pre) {
+		is.logf(is.Fail, skip, prefix, "%q does not start with %q", s, pre)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+HasSuffix asserts that s ends with suffix.
+
+		func TestHasSuffix(t *testing.T) {
+			is := is.New(t)
+			is.HasSuffix(herText(), "xoxo") // hugs and kisses
+		}
+
+Will output:
+
+		is.HasSuffix: "see you" does not end with "xoxo" // hugs and kisses
+*/
+func (is *Is) HasSuffix(s, suffix string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.HasSuffix"
+	skip := 3
+
+	if !strings.HasSuffix(s, suffix) {
+		is.logf(is.Fail, skip, prefix, "%q does not end with %q", s, suffix)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Error asserts that err is one of the expectedErrors.
+Error uses errors.Is to test the error.
+If no expectedErrors is given, any error will output passed the tests.
+Upon failing the test, Error lists the errors joined by errors.Join inside err.
+Error uses t.FailNow upon failing the test.
+
+		func TestError(t *testing.T) {
+			is := is.New(t)
+			_, err := findGirlfriend("Anyone?")
+			is.Error(err, errors.New("coding")) // its not easy
+		}
+
+Will output:
+
+		is.Error: get a girlfriend as programmer? != coding // its not easy
+*/
+func (is *Is) Error(err error, expectedErrors ...error) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Error"
+	skip := 3
+
+	if err == nil {
+		is.logf(is.failNow, skip, prefix, "<nil>")
+		return false
+	}
+
+	lenErr := len(expectedErrors)
+
+	if lenErr == 0 {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	for _, expectedError := range expectedErrors {
+		if errors.Is(err, expectedError) {
+			is.pass(skip, prefix)
+			return true
+		}
+	}
+
+	if errs := joined(err); errs != nil {
+		if lenErr == 1 {
+			is.logf(is.failNow, skip, prefix, "%s does not wrap %s", errs, expectedErrors[0].Error())
+			return false
+		}
+
+		is.logf(is.failNow, skip, prefix, "%s does not wrap any of the expected errors", errs)
+		return false
+	}
+
+	if lenErr == 1 {
+		is.logf(is.failNow, skip, prefix, "%s != %s", err.Error(), expectedErrors[0].Error())
+		return false
+	}
+
+	is.logf(is.failNow, skip, prefix, "%s != one of the expected errors", err.Error())
+	return false
+}
+
+/*
+ErrorAs asserts that err as target. ErrorAs uses errors.As to test the error.
+ErrorAs uses t.FailNow upon failing the test.
+
+		func TestNoError(t *testing.T) {
+			is := is.New(t)
+			err := errors.New("find a way her heart")
+			var pathError *os.PathError
+			is.ErrorAs(err, &pathError) // where should I go?
+		}
+
+Will output:
+
+		is.ErrorAs: err != **os.PathError // where should I go?
+*/
+func (is *Is) ErrorAs(err error, target interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.ErrorAs"
+	skip := 3
+
+	if !errors.As(err, target) {
+		is.logf(is.failNow, skip, prefix, "err != %T", target)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+ErrorContains asserts that the message of err contains substr.
+ErrorContains uses t.FailNow upon failing the test.
+
+		func TestErrorContains(t *testing.T) {
+			is := is.New(t)
+			_, err := findGirlfriend("Anyone?")
+			is.ErrorContains(err, "too busy") // she said
+		}
+
+Will output:
+
+		is.ErrorContains: "girlfriend not found" does not contain "too busy" // she said
+*/
+func (is *Is) ErrorContains(err error, substr string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.ErrorContains"
+	skip := 3
+
+	if err == nil {
+		is.logf(is.failNow, skip, prefix, "<nil>")
+		return false
+	}
+
+	if !strings.Contains(err.Error(), substr) {
+		is.logf(is.failNow, skip, prefix, "%q does not contain %q", err.Error(), substr)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+EqualError asserts that the message of err is msg.
+EqualError uses t.FailNow upon failing the test.
+
+		func TestEqualError(t *testing.T) {
+			is := is.New(t)
+			_, err := findGirlfriend("Anyone?")
+			is.EqualError(err, "it's not you, it's me") // the classic
+		}
+
+Will output:
+
+		is.EqualError: "girlfriend not found" != "it's not you, it's me" // the classic
+*/
+func (is *Is) EqualError(err error, msg string) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.EqualError"
+	skip := 3
+
+	if err == nil {
+		is.logf(is.failNow, skip, prefix, "<nil>")
+		return false
+	}
+
+	if err.Error() != msg {
+		is.logf(is.failNow, skip, prefix, "%q != %q", err.Error(), msg)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NotErrorIs asserts that err is not target. NotErrorIs uses errors.Is to test the error,
+the nil err wraps nothing so it always passes.
+
+		func TestNotErrorIs(t *testing.T) {
+			is := is.New(t)
+			err := dateHer(ctx)
+			is.NotErrorIs(err, context.Canceled) // she came after all
+		}
+
+Will output:
+
+		is.NotErrorIs: error unexpectedly wraps context canceled // she came after all
+*/
+func (is *Is) NotErrorIs(err error, target error) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NotErrorIs"
+	skip := 3
+
+	if errors.Is(err, target) {
+		is.logf(is.Fail, skip, prefix, "error unexpectedly wraps %s", target.Error())
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NoError assert that err is nil. NoError uses t.FailNow upon failing the test.
+
+		func TestNoError(t *testing.T) {
+			is := is.New(t)
+			girl, err := findGirlfriend("Anyone?")
+			is.NoError(err) // i give up
+			is.Equal(girl, nil) // it will not get executed
+		}
+
+Will output:
+
+		is.NoError: girlfriend not found // i give up
+*/
+func (is *Is) NoError(err error) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NoError"
+	skip := 3
+
+	if err != nil {
+		is.logf(is.failNow, skip, prefix, "%s", err.Error())
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+True asserts that expression is true.
+The expression code itself will be reported if the assertion fails.
+
+		func TestTrue(t *testing.T) {
+			is := is.New(t)
+			money := openTheWallet()
+			is.True(money != 0) // money shouldn't be 0 to get a girl
+		}
+
+Will output:
+
+		is.True: money != 0 // money shouldn't be 0 to get a girl
+
+The optional operands are the values of the left and right operands
+of a comparison expression, they are reported along with the expression:
+
+		is.True(money > price, money, price) // can't afford it
+
+Will output:
+
+		is.True: money > price (money == 5, price == 10) // can't afford it
+
+If the source of the test isn't available, e.g. the test binary is built elsewhere,
+the expression is reported as <expression unavailable>, use is.Truef to describe it instead.
+*/
+func (is *Is) True(expression bool, operands ...interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.True"
+	skip := 3
+
+	if expression {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	arg := is.loadArgument(is.callSite())
+	is.logf(is.Fail, skip, prefix, "%s", arg.describe(operands, is.format))
+	return false
+}
+
+/*
+Truef asserts that expression is true. Upon failing the test, Truef reports the message
+formatted by format and args followed by the expression code, if its source is available.
+It describes the computed booleans better than their expression.
+
+		func TestTruef(t *testing.T) {
+			is := is.New(t)
+			age := herAge()
+			is.Truef(isAdult(age), "she is %d", age) // wait a year
+		}
+
+Will output:
+
+		is.Truef: she is 17 (isAdult(age)) // wait a year
+*/
+func (is *Is) Truef(expression bool, format string, args ...interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Truef"
+	skip := 3
+
+	if expression {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if arg := is.loadArgument(is.callSite()); arg.expr != "" {
+		msg += " (" + arg.expr + ")"
+	}
+	is.logf(is.Fail, skip, prefix, "%s", msg)
+	return false
+}
+
+/*
+False asserts that expression is false.
+The expression code itself will be reported if the assertion fails.
+The optional operands are reported the same as is.True.
+
+		func TestFalse(t *testing.T) {
+			is := is.New(t)
+			money := openTheWallet()
+			is.False(money == 0) // money shouldn't be 0 to get a girl
+		}
+
+Will output:
+
+		is.False: money == 0 // money shouldn't be 0 to get a girl
+*/
+func (is *Is) False(expression bool, operands ...interface{}) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.False"
+	skip := 3
+
+	if !expression {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	arg := is.loadArgument(is.callSite())
+	is.logf(is.Fail, skip, prefix, "%s", arg.describe(operands, is.format))
+	return false
+}
+
+/*
+Panic assert that function f is panic.
+
+		func TestPanic(t *testing.T) {
+			is := is.New(t)
+			panicFunc := func() { panic("single") }
+			is.Panic(panicFunc, "really panic", "crazy panic") // ok
+		}
+
+Will output:
+
+		is.Panic: single != one of the expected panic values // ok
+*/
+func (is *Is) Panic(f PanicFunc, expectedValues ...interface{}) (ok bool) {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+
+	defer func(expectedValues ...interface{}) {
+		is.Helper()
+		prefix := "is.Panic"
+		skip := 4
+
+		r := recover()
+		if r == nil {
+			is.logf(is.Fail, skip, prefix, "the function is not panic")
+			return
+		}
+
+		lenVal := len(expectedValues)
+
+		if lenVal == 0 {
+			ok = true
+			is.pass(skip, prefix)
+			return
+		}
+
+		for _, v := range expectedValues {
+			if reflect.DeepEqual(r, v) {
+				ok = true
+				is.pass(skip, prefix)
+				return
+			}
+		}
+
+		if lenVal == 1 {
+			is.logf(is.Fail, skip, prefix, "%v != %v", r, expectedValues[0])
+			return
+		}
+
+		is.logf(is.Fail, skip, prefix, "%v != one of the expected panic values", r)
+	}(expectedValues...)
+
+	f()
+	return false
+}
+
+/*
+PanicAs asserts that function f is panic with a value assignable to the value pointed by target,
+then assigns the panic value to it, so the panic value can be inspected afterward.
+PanicAs panics if target is not a non-nil pointer.
+
+		func TestPanicAs(t *testing.T) {
+			is := is.New(t)
+			var rejection *Rejection
+			is.PanicAs(func() { askHerOut() }, &rejection) // brace yourself
+			is.Equal(rejection.Reason, "it's not you, it's me")
+		}
+
+Will output:
+
+		is.PanicAs: panic value string is not *Rejection // brace yourself
+*/
+func (is *Is) PanicAs(f PanicFunc, target interface{}) (ok bool) {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		panic("is: target must be a non-nil pointer")
+	}
+
+	is.Helper()
+
+	defer func() {
+		is.Helper()
+		prefix := "is.PanicAs"
+		skip := 4
+
+		r := recover()
+		if r == nil {
+			is.logf(is.Fail, skip, prefix, "the function is not panic")
+			return
+		}
+
+		elem := val.Elem()
+		rv := reflect.ValueOf(r)
+		if !rv.Type().AssignableTo(elem.Type()) {
+			is.logf(is.Fail, skip, prefix, "panic value %T is not %s", r, elem.Type())
+			return
+		}
+
+		elem.Set(rv)
+		ok = true
+		is.pass(skip, prefix)
+	}()
+
+	f()
+	return false
+}
+
+/*
+PanicMatches asserts that function f is panic with a value matching the regular expression pattern.
+The panic value is converted to string by fmt.Sprint.
+PanicMatches uses t.FailNow without calling f if pattern is invalid.
+
+		func TestPanicMatches(t *testing.T) {
+			is := is.New(t)
+			is.PanicMatches(func() { countTheGirlfriends() }, "out of bounds") // expected none
+		}
+
+Will output:
+
+		is.PanicMatches: "index 7 out of range" does not match "out of bounds" // expected none
+*/
+func (is *Is) PanicMatches(f PanicFunc, pattern string) (ok bool) {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		is.logf(is.failNow, 3, "is.PanicMatches", "invalid pattern %q: %s", pattern, err)
+		return false
+	}
+
+	defer func() {
+		is.Helper()
+		prefix := "is.PanicMatches"
+		skip := 4
+
+		r := recover()
+		if r == nil {
+			is.logf(is.Fail, skip, prefix, "the function is not panic")
+			return
+		}
+
+		if s := fmt.Sprint(r); !re.MatchString(s) {
+			is.logf(is.Fail, skip, prefix, "%q does not match %q", s, pattern)
+			return
+		}
+
+		ok = true
+		is.pass(skip, prefix)
+	}()
+
+	f()
+	return false
+}
+
+/*
+Eventually asserts that condition returns true within timeout,
+condition is checked right away and then every interval.
+Eventually uses t.FailNow upon failing the test or if interval isn't positive.
+
+		func TestEventually(t *testing.T) {
+			is := is.New(t)
+			replied := func() bool { return len(inbox()) > 0 }
+			is.Eventually(replied, 2*time.Second, 100*time.Millisecond) // still waiting
+		}
+
+Will output:
+
+		is.Eventually: condition not met within 2s // still waiting
+*/
+func (is *Is) Eventually(condition func() bool, timeout, interval time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Eventually"
+	skip := 3
+
+	if interval <= 0 {
+		is.logf(is.failNow, skip, prefix, "interval must be positive, got %s", interval)
+		return false
+	}
+
+	if poll(condition, timeout, interval) {
+		is.pass(skip, prefix)
+		return true
+	}
+
+	is.logf(is.failNow, skip, prefix, "condition not met within %s", timeout)
+	return false
+}
+
+/*
+Never asserts that condition never returns true during duration,
+condition is checked right away and then every interval.
+Never uses t.FailNow upon failing the test or if interval isn't positive.
+
+		func TestNever(t *testing.T) {
+			is := is.New(t)
+			leaving := func() bool { return her.Gone() }
+			is.Never(leaving, time.Second, 100*time.Millisecond) // stay with me
+		}
+
+Will output:
+
+		is.Never: condition became true after 300ms // stay with me
+*/
+func (is *Is) Never(condition func() bool, duration, interval time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Never"
+	skip := 3
+
+	if interval <= 0 {
+		is.logf(is.failNow, skip, prefix, "interval must be positive, got %s", interval)
+		return false
+	}
+
+	start := time.Now()
+	if poll(condition, duration, interval) {
+		is.logf(is.failNow, skip, prefix, "condition became true after %s", time.Since(start).Truncate(interval))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Receives asserts that want is received from the channel ch within timeout,
+the received value is compared to want by reflect.DeepEqual.
+ch can be a channel of any element type. Receives uses t.FailNow upon failing the test.
+
+		func TestReceives(t *testing.T) {
+			is := is.New(t)
+			replies := textHer("hi")
+			is.Receives(replies, "hi too", time.Second) // she's typing...
+		}
+
+Will output:
+
+		is.Receives: no value on channel within 1s // she's typing...
+*/
+func (is *Is) Receives(ch interface{}, want interface{}, timeout time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Receives"
+	skip := 3
+
+	v, ok, received := receive(ch, timeout)
+	if !received {
+		is.logf(is.failNow, skip, prefix, "no value on channel within %s", timeout)
+		return false
+	}
+
+	if !ok {
+		is.logf(is.failNow, skip, prefix, "channel closed, want %s", is.format(want))
+		return false
+	}
+
+	if got := v.Interface(); !reflect.DeepEqual(got, want) {
+		is.logf(is.failNow, skip, prefix, "got %s, want %s", is.format(got), is.format(want))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+Closed asserts that the channel ch is closed within timeout,
+ch can be a channel of any element type. Closed uses t.FailNow upon failing the test,
+including when a value is received instead.
+
+		func TestClosed(t *testing.T) {
+			is := is.New(t)
+			done := breakUp()
+			is.Closed(done, time.Second) // let it go
+		}
+
+Will output:
+
+		is.Closed: channel not closed within 1s // let it go
+*/
+func (is *Is) Closed(ch interface{}, timeout time.Duration) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.Closed"
+	skip := 3
+
+	v, ok, received := receive(ch, timeout)
+	if !received {
+		is.logf(is.failNow, skip, prefix, "channel not closed within %s", timeout)
+		return false
+	}
+
+	if ok {
+		is.logf(is.failNow, skip, prefix, "received %s, the channel is not closed", is.format(v.Interface()))
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+NoGoroutineLeak asserts that body doesn't leave the goroutines it starts running.
+The goroutines are given a second to exit after body returns, then the remaining ones
+are reported along with their stacks. The goroutines started by the other tests
+running in parallel are counted as well, so don't use it in the parallel tests.
+
+		func TestNoGoroutineLeak(t *testing.T) {
+			is := is.New(t)
+			is.NoGoroutineLeak(func() {
+				go waitForHer() // forever
+			}) // let her go
+		}
+
+Will output:
+
+		is.NoGoroutineLeak: 1 leaked goroutines // let her go
+			goroutine 7 [chan receive]:
+			main.waitForHer()
+				/home/me/girl_test.go:12 +0x25
+			...
+*/
+func (is *Is) NoGoroutineLeak(body func()) bool {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	prefix := "is.NoGoroutineLeak"
+	skip := 3
+
+	before := goroutines()
+	body()
+
+	var leaked []string
+	poll(func() bool {
+		leaked = leaked[:0]
+		for id, stack := range goroutines() {
+			if _, ok := before[id]; !ok {
+				leaked = append(leaked, stack)
+			}
+		}
+		return len(leaked) == 0
+	}, leakTimeout, 10*time.Millisecond)
+
+	if len(leaked) > 0 {
+		sort.Strings(leaked)
+		stacks := strings.ReplaceAll(strings.Join(leaked, "\n"), "\n", "\n\t")
+		is.logf(is.Fail, skip, prefix, "%d leaked goroutines\n\t%s", len(leaked), stacks)
+		return false
+	}
+
+	is.pass(skip, prefix)
+	return true
+}
+
+/*
+DebugCaller logs the file, line and function resolved at each skip level from 0 to 6
+the way the assertions resolve their caller, to debug the assertions reporting the wrong line,
+e.g. upon wrapping the test helper. The assertions report the line of skip level 3.
+
+		func TestDebugCaller(t *testing.T) {
+			is := is.New(t)
+			is.DebugCaller()
+		}
+
+Will output:
+
+		is.DebugCaller:
+			skip 0: util.go:720 github.com/billyzaelani/is.frame
+			skip 1: util.go:710 github.com/billyzaelani/is.(*Is).frames
+			skip 2: is.go:1405 github.com/billyzaelani/is.(*Is).DebugCaller
+			skip 3: girl_test.go:12 github.com/me/girl.TestDebugCaller
+			...
+*/
+func (is *Is) DebugCaller() {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	is.log(is.prefixed("is.DebugCaller") + ":\n" + strings.Join(is.frames(), "\n"))
+}
+
+/*
+Failf logs the message formatted by format and args then marks the test as failed,
+for the branches that shouldn't be reached such as the default case of a switch.
+
+		func TestFailf(t *testing.T) {
+			is := is.New(t)
+			switch answer := askHerOut() {
+			case "yes", "maybe":
+			default:
+				is.Failf("unexpected answer %q", answer) // be brave
+			}
+		}
+
+Will output:
+
+		is.Failf: unexpected answer "no" // be brave
+*/
+func (is *Is) Failf(format string, args ...interface{}) {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	is.logf(is.Fail, 3, "is.Failf", format, args...)
+}
+
+/*
+FailNowf logs the message formatted by format and args then marks the test as failed
+and stops its execution by t.FailNow.
+
+		func TestFailNowf(t *testing.T) {
+			is := is.New(t)
+			girl, ok := findGirlfriend("Anyone?")
+			if !ok {
+				is.FailNowf("no girlfriend for %s", "me") // forever alone
+			}
+			is.Equal(girl.Name, "Alice") // it will not get executed
+		}
+
+Will output:
+
+		is.FailNowf: no girlfriend for me // forever alone
+*/
+func (is *Is) FailNowf(format string, args ...interface{}) {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	is.logf(is.failNow, 3, "is.FailNowf", format, args...)
+}
+
+/*
+Summary logs the number of failures collected in relaxed mode followed by their messages.
+The test helpers made by is.New of a relaxed is share the same collection.
+
+		func TestSummary(t *testing.T) {
+			is := is.New(t, is.Relaxed())
+			is.Equal(1, 2)
+			is.NoError(errors.New("girlfriend not found"))
+			is.Summary()
+		}
+
+Will output:
+
+		is.Equal: 1 != 2
+		is.NoError: girlfriend not found
+		is.Summary: 2 failures
+		is.Equal: 1 != 2
+		is.NoError: girlfriend not found
+*/
+func (is *Is) Summary() {
+	if is.T == nil {
+		panic("is: T is nil")
+	}
+
+	is.Helper()
+	messages := is.failures.list()
+	summary := []string{fmt.Sprintf("%s: %d failures", is.prefixed("is.Summary"), len(messages))}
+	is.log(strings.Join(append(summary, messages...), "\n"))
+}
+
+/*
+LastMessage returns the last failure message logged by is, or empty if nothing failed yet.
+It helps to test the output of the custom assertions built on top of is without a mock of T.
+
+		func TestLastMessage(t *testing.T) {
+			is := is.New(t, is.Relaxed())
+			is.Equal(girlfriend(), "Alice")
+			if is.LastMessage() != "is.Equal: Bob != Alice" {
+				t.Error("who is Bob?")
+			}
+		}
+*/
+func (is *Is) LastMessage() string {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	return is.last
 }
 
 // PanicFunc is a function to test that function call is panic or not.
 type PanicFunc func()
 
 // T is the subset of testing.T used by the package is.
+// Any testing.TB satisfies T, so *testing.B and *testing.F can be passed to New as well.
+// Within the fuzz function of f.Fuzz, create the test helper from its *testing.T instead of f.
 type T interface {
 	Fail()
 	FailNow()
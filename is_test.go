@@ -1,8 +1,20 @@
 package is_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	assert "github.com/billyzaelani/is"
 )
@@ -30,19 +42,2355 @@ func TestEqual(t *testing.T) {
 			func(is *assert.Is) { is.Equal(int32(1), int64(2)) }},
 		{"with nil", fail, prefix + `<nil> != string(nil)`,
 			func(is *assert.Is) { is.Equal(nil, "nil") }},
-		{"nil slice", fail, prefix + `[] != [one two]`,
+		{"nil slice", fail, prefix + "len 0 != len 2\n\t[] != [one two]",
 			func(is *assert.Is) { is.Equal([]string{}, []string{"one", "two"}) }},
 		{"nil with slice", fail, prefix + `<nil> != []string([one two])`,
 			func(is *assert.Is) { is.Equal(nil, []string{"one", "two"}) }},
+		{"slice with its element", fail, prefix + `[]int != int (did you mean to index the slice?)`,
+			func(is *assert.Is) { is.Equal([]int{1, 2, 3}, 1) }},
+		{"element with its array", fail, prefix + `string != [2]string (did you mean to index the slice?)`,
+			func(is *assert.Is) { is.Equal("one", [2]string{"one", "two"}) }},
+		{"map with its struct", fail, prefix + `map[string]int != is_test.point (comparing a decoded map to a struct?)`,
+			func(is *assert.Is) { is.Equal(map[string]int{"X": 1, "y": 2}, point{1, 2}) }},
+		{"struct with its map", fail, prefix + `is_test.QueryError != map[string]interface {} (comparing a decoded map to a struct?)`,
+			func(is *assert.Is) { is.Equal(QueryError{"SELECT"}, map[string]interface{}{"query": "SELECT"}) }},
+		{"map with other struct", fail, prefix + `map[string]int(map[Z:1]) != is_test.point({1 2})`,
+			func(is *assert.Is) { is.Equal(map[string]int{"Z": 1}, point{1, 2}) }},
+		{"different implementations", fail, prefix + `*strings.Reader(&{a 0 -1}) != *bytes.Buffer(a) (both implement io.Reader)`,
+			func(is *assert.Is) { is.Equal(strings.NewReader("a"), bytes.NewBufferString("a")) }},
+		{"different stringers", fail, prefix + `time.Duration(1s) != is_test.build(v1) (both implement fmt.Stringer)`,
+			func(is *assert.Is) { is.Equal(time.Second, build{"v1", 1}) }},
+		{"different errors", fail, prefix + `"something's wrong" is not "query: SELECT"`,
+			func(is *assert.Is) { is.Equal(errWrong, &QueryError{"SELECT"}) }},
+		{"wrapped error", pass, ``,
+			func(is *assert.Is) { is.Equal(fmt.Errorf("wrapped: %w", errWrong), errWrong) }},
+		{"error wrapped by the other", pass, ``,
+			func(is *assert.Is) { is.Equal(errWrong, fmt.Errorf("wrapped: %w", errWrong)) }},
+		{"same error message", pass, ``,
+			func(is *assert.Is) { is.Equal(errors.New("boom"), errors.New("boom")) }},
+		{"not wrapped error", fail, prefix + `"wrapped: boom" is not "boom" // lost the cause`,
+			func(is *assert.Is) {
+				is.Equal(fmt.Errorf("wrapped: %v", errors.New("boom")), errors.New("boom")) /* lost the cause */
+			}},
+		{"slice with other element", fail, prefix + `[]int([1 2 3]) != string(1)`,
+			func(is *assert.Is) { is.Equal([]int{1, 2, 3}, "1") }},
 		{"with comment", fail, prefix + `foo != bar // foo is not bar`,
 			func(is *assert.Is) { is.Equal("foo", "bar") /* foo is not bar */ }},
+		{"with leading comment", fail, prefix + `foo != bar // foo is not bar`,
+			func(is *assert.Is) { /* leading */ is.Equal("foo", "bar") /* foo is not bar */ }},
+	}
+
+	for _, tt := range tests {
+		// fix for-range local variable when using t.Parallel that reduces code coverage
+		// see: https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEqualValues(t *testing.T) {
+	prefix := "is.EqualValues: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.EqualValues([]int{1}, []int{1}) }},
+		{"different integer types", pass, ``,
+			func(is *assert.Is) { is.EqualValues(int32(1), int64(1)) }},
+		{"signed and unsigned", pass, ``,
+			func(is *assert.Is) { is.EqualValues(uint8(255), 255) }},
+		{"integer and float", pass, ``,
+			func(is *assert.Is) { is.EqualValues(3, float32(3)) }},
+		{"integer and float beyond 2^53", pass, ``,
+			func(is *assert.Is) { is.EqualValues(int64(1<<53), float64(1<<53)) }},
+		{"unsigned and float", pass, ``,
+			func(is *assert.Is) { is.EqualValues(float64(1<<63), uint64(1<<63)) }},
+		{"not equal", fail, prefix + `int32(1) != int64(2) // one is not two`,
+			func(is *assert.Is) { is.EqualValues(int32(1), int64(2)) /* one is not two */ }},
+		{"negative and unsigned", fail, prefix + `int(-1) != uint64(18446744073709551615)`,
+			func(is *assert.Is) { is.EqualValues(-1, ^uint64(0)) }},
+		{"fraction", fail, prefix + `float64(1.5) != int(1)`,
+			func(is *assert.Is) { is.EqualValues(1.5, 1) }},
+		{"precision", fail, prefix + `int64(9007199254740993) != float64(9.007199254740992e+15)`,
+			func(is *assert.Is) { is.EqualValues(int64(1<<53+1), float64(1<<53)) }},
+		{"float out of range", fail, prefix + `float64(9.223372036854776e+18) != int64(9223372036854775807)`,
+			func(is *assert.Is) { is.EqualValues(float64(1<<63), int64(math.MaxInt64)) }},
+		{"infinity", fail, prefix + `float64(+Inf) != int(0)`,
+			func(is *assert.Is) { is.EqualValues(math.Inf(1), 0) }},
+		{"not a number", fail, prefix + `string(1) != int(1)`,
+			func(is *assert.Is) { is.EqualValues("1", 1) }},
+		{"nil", fail, prefix + `<nil> != int(0)`,
+			func(is *assert.Is) { is.EqualValues(nil, 0) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEqualOK(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		want bool
+	}{
+		{"equal", 1, 1, true},
+		{"not equal", 1, 2, false},
+		{"both nil", nil, nil, true},
+		{"with nil", nil, "nil", false},
+		{"different data type", int32(1), int64(1), false},
+		{"same binary form", fraction{1, 2}, fraction{2, 4}, true},
+		{"different binary form", fraction{1, 2}, fraction{2, 3}, false},
+		{"same inner value", Any{"Foo", Foo{1}, 0}, Any{"Foo", Foo{1}, 8}, true},
+		{"different inner type", Any{"Foo", Foo{1}, 0}, Any{"Bar", Bar{1}, 0}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+
+			if got := is.EqualOK(tt.a, tt.b); got != tt.want {
+				t.Errorf("%v != %v", got, tt.want)
+			}
+			assertState(t, m.state, pass)
+			if m.msg != "" {
+				t.Errorf("%q != %q", m.msg, "")
+			}
+		})
+	}
+}
+
+// fraction marshals to its reduced form, so 1/2 and 2/4 have the same binary form.
+type fraction struct{ num, den int }
+
+func (f fraction) MarshalBinary() ([]byte, error) {
+	a, b := f.num, f.den
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return []byte{byte(f.num / a), byte(f.den / a)}, nil
+}
+
+// build renders only its version, the build number tells apart the same versions.
+type build struct {
+	version string
+	number  byte
+}
+
+func (b build) String() string                 { return b.version }
+func (b build) MarshalBinary() ([]byte, error) { return []byte{b.number}, nil }
+
+func TestEqualBinaryMarshaler(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"same binary form", pass, ``,
+			func(is *assert.Is) { is.Equal(fraction{1, 2}, fraction{2, 4}) }},
+		{"different binary form", fail, prefix + `{1 2} != {2 3}`,
+			func(is *assert.Is) { is.Equal(fraction{1, 2}, fraction{2, 3}) }},
+		{"same rendering", fail, prefix + `v1 != v1 (binary 01 != 02)`,
+			func(is *assert.Is) { is.Equal(build{"v1", 1}, build{"v1", 2}) }},
+		{"time", fail, prefix + `1970-01-01 00:00:01 +0000 UTC != 1970-01-01 00:00:02 +0000 UTC`,
+			func(is *assert.Is) { is.Equal(time.Unix(1, 0).UTC(), time.Unix(2, 0).UTC()) }},
+		{"different data type", fail, prefix + `is_test.fraction({1 2}) != string(1/2)`,
+			func(is *assert.Is) { is.Equal(fraction{1, 2}, "1/2") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+// lazy doubles its seed upon the first use.
+type lazy struct {
+	once  sync.Once
+	mu    sync.Mutex
+	Seed  int
+	value int
+}
+
+func (l *lazy) Init() { l.once.Do(func() { l.value = l.Seed * 2 }) }
+
+func TestEqualLazy(t *testing.T) {
+	initialized := func(seed int) *lazy {
+		l := &lazy{Seed: seed}
+		l.Init()
+		return l
+	}
+
+	tests := []struct {
+		name  string
+		state failState
+		lines []string // lines the message must contain
+		f     func(is *assert.Is)
+	}{
+		{"initialized and not", pass, nil,
+			func(is *assert.Is) { is.EqualLazy(&lazy{Seed: 1}, initialized(1)) }},
+		{"both not initialized", pass, nil,
+			func(is *assert.Is) { is.EqualLazy(&lazy{Seed: 1}, &lazy{Seed: 1}) }},
+		{"without init method", pass, nil,
+			func(is *assert.Is) { is.EqualLazy(user{"Alice", 17, nil}, user{"Alice", 17, nil}) }},
+		{"different", fail, []string{"is.EqualLazy: values differ // seed", "Seed", "1", "2"},
+			func(is *assert.Is) { is.EqualLazy(&lazy{Seed: 1}, initialized(2)) /* seed */ }},
+		{"nil", fail, []string{"is.EqualLazy: <nil> != *is_test.lazy(&{"},
+			func(is *assert.Is) { is.EqualLazy(nil, &lazy{}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			for _, line := range tt.lines {
+				if !strings.Contains(m.msg, line) {
+					t.Errorf("%q doesn't contain %q", m.msg, line)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizedEqual(t *testing.T) {
+	prefix := "is.NormalizedEqual: "
+	type config struct {
+		Name  string
+		Ports []int
+	}
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual(1, 1) }},
+		{"int and float", pass, ``,
+			func(is *assert.Is) {
+				is.NormalizedEqual(map[string]interface{}{"n": 3}, map[string]interface{}{"n": 3.0})
+			}},
+		{"different integer types", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual([]int32{1, 2}, []uint8{1, 2}) }},
+		{"nil and empty slice", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual([]string(nil), []string{}) }},
+		{"nil and empty map", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual(map[string]int(nil), map[string]int{}) }},
+		{"struct and decoded map", pass, ``,
+			func(is *assert.Is) {
+				is.NormalizedEqual(config{"db", []int{5432}},
+					map[string]interface{}{"Name": "db", "Ports": []interface{}{5432.0}})
+			}},
+		{"pointer", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual(&config{"db", nil}, config{"db", []int{}}) }},
+		{"fraction", fail, prefix + `map[n:3] != map[n:3.5] (normalized) // rounded`,
+			func(is *assert.Is) {
+				is.NormalizedEqual(map[string]interface{}{"n": 3}, map[string]interface{}{"n": 3.5}) // rounded
+			}},
+		{"different values", fail, prefix + `map[Name:db Ports:[5432]] != map[Name:db Ports:[5433]] (normalized)`,
+			func(is *assert.Is) {
+				is.NormalizedEqual(config{"db", []int{5432}},
+					map[string]interface{}{"Name": "db", "Ports": []interface{}{5433.0}})
+			}},
+		{"nil slice and nil map", pass, ``,
+			func(is *assert.Is) { is.NormalizedEqual([]int(nil), map[string]int(nil)) }},
+		{"null and empty array", pass, ``,
+			func(is *assert.Is) {
+				is.NormalizedEqual(map[string]interface{}{"a": nil}, map[string]interface{}{"a": []interface{}{}})
+			}},
+		{"null and empty object", pass, ``,
+			func(is *assert.Is) {
+				is.NormalizedEqual(map[string]interface{}{"a": nil}, map[string]interface{}{"a": map[string]interface{}{}})
+			}},
+		{"empty and non-empty", fail, prefix + `map[a:<nil>] != map[a:[1]] (normalized)`,
+			func(is *assert.Is) {
+				is.NormalizedEqual(map[string]interface{}{"a": []int{}}, map[string]interface{}{"a": []int{1}})
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+type handler func(int) error
+
+func TestSignatureEqual(t *testing.T) {
+	prefix := "is.SignatureEqual: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"same signature", pass, ``,
+			func(is *assert.Is) {
+				is.SignatureEqual(func(int) error { return nil }, func(n int) error { return errWrong })
+			}},
+		{"named type", pass, ``,
+			func(is *assert.Is) { is.SignatureEqual(handler(nil), func(int) error { return nil }) }},
+		{"no result", pass, ``,
+			func(is *assert.Is) { is.SignatureEqual(func() {}, t.Parallel) }},
+		{"different parameter", fail, prefix + `func(int) error != func(string) error // param`,
+			func(is *assert.Is) {
+				is.SignatureEqual(func(int) error { return nil }, func(string) error { return nil }) // param
+			}},
+		{"variadic", fail, prefix + `func(...int) != func([]int)`,
+			func(is *assert.Is) { is.SignatureEqual(func(...int) {}, func([]int) {}) }},
+		{"multiple results", fail, prefix + `func(string, bool) (int, error) != func(string, bool) int`,
+			func(is *assert.Is) {
+				is.SignatureEqual(func(string, bool) (int, error) { return 0, nil }, func(string, bool) int { return 0 })
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("not a func", func(t *testing.T) {
+		defer func() {
+			errMsg := "is: int is not a func"
+			if err := recover(); err != errMsg {
+				t.Errorf("%q != %q", err, errMsg)
+			}
+		}()
+		is.New(new(mockT)).SignatureEqual(func() {}, 1)
+	})
+}
+
+func TestJSONEqual(t *testing.T) {
+	prefix := "is.JSONEqual: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`{"a":1,"b":[true,null]}`, `{"a":1,"b":[true,null]}`) }},
+		{"reordered keys", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`{"a":1,"b":2}`, `{"b":2,"a":1}`) }},
+		{"different whitespace", pass, ``,
+			func(is *assert.Is) { is.JSONEqual("{\n\t\"a\": [1, 2]\n}", `{"a":[1,2]}`) }},
+		{"not equal", fail, prefix + "{\"a\":1,\"b\":2} != {\"a\":1,\"b\":3} // b changed\n\tat $.b: 2 != 3",
+			func(is *assert.Is) { is.JSONEqual(`{"b":2, "a":1}`, `{"a":1,"b":3}`) /* b changed */ }},
+		{"string number", fail, prefix + "{\"n\":\"3\"} != {\"n\":3}\n\tat $.n: \"3\" != 3",
+			func(is *assert.Is) { is.JSONEqual(`{"n":"3"}`, `{"n":3}`) }},
+		{"nested", fail, prefix + "{\"items\":[{\"id\":1},{\"id\":2}]} != {\"items\":[{\"id\":1},{\"id\":3}]}\n\tat $.items[1].id: 2 != 3",
+			func(is *assert.Is) { is.JSONEqual(`{"items":[{"id":1},{"id":2}]}`, `{"items":[{"id":1},{"id":3}]}`) }},
+		{"missing key", fail, prefix + "{\"a\":1} != {\"a\":1,\"b\":2}\n\tat $.b: missing in a",
+			func(is *assert.Is) { is.JSONEqual(`{"a":1}`, `{"a":1,"b":2}`) }},
+		{"different length", fail, prefix + "{\"a\":[1]} != {\"a\":[1,2]}\n\tat $.a: len 1 != len 2",
+			func(is *assert.Is) { is.JSONEqual(`{"a":[1]}`, `{"a":[1,2]}`) }},
+		{"different root", fail, prefix + `[1] != {"a":1}`,
+			func(is *assert.Is) { is.JSONEqual(`[1]`, `{"a":1}`) }},
+		{"invalid a", fail, prefix + `invalid JSON in a: unexpected end of JSON input`,
+			func(is *assert.Is) { is.JSONEqual(`{"a":`, `{}`) }},
+		{"invalid b", fail, prefix + `invalid JSON in b: invalid character 'x' looking for beginning of value`,
+			func(is *assert.Is) { is.JSONEqual(`{}`, `x`) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestJSONEqualBytes(t *testing.T) {
+	prefix := "is.JSONEqualBytes: "
+	type profile struct {
+		Name    string   `json:"name"`
+		Single  bool     `json:"single"`
+		Hobbies []string `json:"hobbies"`
+	}
+	alice, err := json.Marshal(profile{"Alice", false, []string{"coding"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"marshaled struct", pass, ``,
+			func(is *assert.Is) {
+				is.JSONEqualBytes(alice, []byte(`{"hobbies": ["coding"], "single": false, "name": "Alice"}`))
+			}},
+		{"not equal", fail, prefix + "documents differ // too good to be true" + `
+	at $.single: false != true
+	a: {
+	  "hobbies": [
+	    "coding"
+	  ],
+	  "name": "Alice",
+	  "single": false
+	}
+	b: {
+	  "hobbies": [
+	    "coding"
+	  ],
+	  "name": "Alice",
+	  "single": true
+	}`,
+			func(is *assert.Is) {
+				is.JSONEqualBytes(alice, []byte(`{"name": "Alice", "single": true, "hobbies": ["coding"]}`)) // too good to be true
+			}},
+		{"different root", fail, prefix + "documents differ\n\ta: 1\n\tb: \"1\"",
+			func(is *assert.Is) { is.JSONEqualBytes([]byte(`1`), []byte(`"1"`)) }},
+		{"invalid a", fail, prefix + `invalid JSON in a: unexpected end of JSON input`,
+			func(is *assert.Is) { is.JSONEqualBytes(nil, alice) }},
+		{"invalid b", fail, prefix + `invalid JSON in b: invalid character 'x' looking for beginning of value`,
+			func(is *assert.Is) { is.JSONEqualBytes(alice, []byte(`x`)) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestWithLooseJSON(t *testing.T) {
+	prefix := "is.JSONEqual: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"string number", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`{"n":"3"}`, `{"n":3}`) }},
+		{"string boolean", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`["true","false"]`, `[true,false]`) }},
+		{"nested", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`{"a":[{"n":"1.5"}]}`, `{"a":[{"n":1.5}]}`) }},
+		{"string", pass, ``,
+			func(is *assert.Is) { is.JSONEqual(`"yes"`, `"yes"`) }},
+		{"not equal", fail, prefix + "{\"n\":3} != {\"n\":4} (coerced)\n\tat $.n: 3 != 4",
+			func(is *assert.Is) { is.JSONEqual(`{"n":"3"}`, `{"n":4}`) }},
+		{"not a number", fail, prefix + "{\"n\":\"NaN\"} != {\"n\":3} (coerced)\n\tat $.n: \"NaN\" != 3",
+			func(is *assert.Is) { is.JSONEqual(`{"n":"NaN"}`, `{"n":3}`) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.WithLooseJSON())
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestInDelta(t *testing.T) {
+	prefix := "is.InDelta: "
+	a, b := 0.1, 0.2
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.InDelta(0.3, 0.3, 0) }},
+		{"within delta", pass, ``,
+			func(is *assert.Is) { is.InDelta(a+b, 0.3, 1e-9) }},
+		{"negative difference", pass, ``,
+			func(is *assert.Is) { is.InDelta(0.3, a+b, 1e-9) }},
+		{"infinity", pass, ``,
+			func(is *assert.Is) { is.InDelta(1, 2, math.Inf(1)) }},
+		{"both infinity", pass, ``,
+			func(is *assert.Is) { is.InDelta(math.Inf(1), math.Inf(1), 0) }},
+		{"exceeded", fail, prefix + `0.30000000000000004 and 0.3 differ by 5.551115123125783e-17, allowed 0 // float`,
+			func(is *assert.Is) { is.InDelta(a+b, 0.3, 0) /* float */ }},
+		{"NaN", fail, prefix + `NaN is not comparable`,
+			func(is *assert.Is) { is.InDelta(math.NaN(), 0, 1) }},
+		{"NaN delta", fail, prefix + `1 and 2 differ by 1, allowed NaN`,
+			func(is *assert.Is) { is.InDelta(1, 2, math.NaN()) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestInEpsilon(t *testing.T) {
+	prefix := "is.InEpsilon: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.InEpsilon(0.3, 0.3, 0) }},
+		{"within epsilon", pass, ``,
+			func(is *assert.Is) { is.InEpsilon(101, 100, 0.01) }},
+		{"large magnitude", pass, ``,
+			func(is *assert.Is) { is.InEpsilon(1e20+1e17, 1e20, 0.01) }},
+		{"negative", pass, ``,
+			func(is *assert.Is) { is.InEpsilon(-99, -100, 0.01) }},
+		{"both zero", pass, ``,
+			func(is *assert.Is) { is.InEpsilon(0, 0, 0) }},
+		{"exceeded", fail, prefix + `2 and 1 differ by relative error 1, allowed 0.5 // double`,
+			func(is *assert.Is) { is.InEpsilon(2, 1, 0.5) /* double */ }},
+		{"zero", fail, prefix + `relative error of 1 to 0 is undefined`,
+			func(is *assert.Is) { is.InEpsilon(1, 0, 1) }},
+		{"NaN", fail, prefix + `NaN is not comparable`,
+			func(is *assert.Is) { is.InEpsilon(1, math.NaN(), 1) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	prefix := "is.Match: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"match", pass, ``,
+			func(is *assert.Is) { is.Match("v1.2.3", `^v\d`) }},
+		{"not match", fail, prefix + `"hello" does not match "^world"`,
+			func(is *assert.Is) { is.Match("hello", "^world") }},
+		{"with comment", fail, prefix + `"latest" does not match "^v\\d" // version tag`,
+			func(is *assert.Is) { is.Match("latest", "^v\\d") /* version tag */ }},
+		{"invalid pattern", fail, prefix + `invalid pattern "[": error parsing regexp: missing closing ]: ` + "`[`",
+			func(is *assert.Is) { is.Match("hello", "[") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNotMatch(t *testing.T) {
+	prefix := "is.NotMatch: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"not match", pass, ``,
+			func(is *assert.Is) { is.NotMatch("hello", "^world") }},
+		{"match", fail, prefix + `"hello" matches "^h" // greeting`,
+			func(is *assert.Is) { is.NotMatch("hello", "^h") /* greeting */ }},
+		{"invalid pattern", fail, prefix + `invalid pattern "[": error parsing regexp: missing closing ]: ` + "`[`",
+			func(is *assert.Is) { is.NotMatch("hello", "[") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNotEqual(t *testing.T) {
+	prefix := "is.NotEqual: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"not equal", pass, ``,
+			func(is *assert.Is) { is.NotEqual(1, 2) }},
+		{"different data type", pass, ``,
+			func(is *assert.Is) { is.NotEqual(int32(1), int64(1)) }},
+		{"nil with typed nil", pass, ``,
+			func(is *assert.Is) { is.NotEqual(nil, []string(nil)) }},
+		{"equal", fail, prefix + `int(1) == int(1)`,
+			func(is *assert.Is) { is.NotEqual(1, 1) }},
+		{"both nil", fail, prefix + `<nil> == <nil>`,
+			func(is *assert.Is) { is.NotEqual(nil, nil) }},
+		{"both typed nil", fail, prefix + `[]string([]) == []string([])`,
+			func(is *assert.Is) { is.NotEqual([]string(nil), []string(nil)) }},
+		{"with comment", fail, prefix + `string(foo) == string(foo) // foo is foo`,
+			func(is *assert.Is) { is.NotEqual("foo", "foo") /* foo is foo */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNotEqualOptions(t *testing.T) {
+	prefix := "is.NotEqual: "
+	tests := []struct {
+		name  string
+		opts  []assert.Option
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"RegisterEqual", nil, fail, prefix + `is_test.Money({1 USD}) == is_test.Money({1 usd})`,
+			func(is *assert.Is) { is.NotEqual(Money{1, "USD"}, Money{1, "usd"}) }},
+		{"WithComparer", []assert.Option{assert.WithComparer(func(a, b meters) bool { return math.Abs(float64(a-b)) < 1e-3 })},
+			fail, prefix + `is_test.meters(1) == is_test.meters(1.0001)`,
+			func(is *assert.Is) { is.NotEqual(meters(1), meters(1.0001)) }},
+		{"NilMapSliceEqual", []assert.Option{assert.NilMapSliceEqual()}, fail, prefix + `[]int([]) == []int([])`,
+			func(is *assert.Is) { is.NotEqual([]int(nil), []int{}) }},
+		{"without NilMapSliceEqual", nil, pass, ``,
+			func(is *assert.Is) { is.NotEqual([]int(nil), []int{}) }},
+		{"wrapped error", nil, fail, prefix + `*fmt.wrapError(wrapped: something's wrong) == *errors.errorString(something's wrong)`,
+			func(is *assert.Is) { is.NotEqual(fmt.Errorf("wrapped: %w", errWrong), errWrong) }},
+		{"unboxed", nil, fail, prefix + `is_test.Any({Foo {1} 0}) == is_test.Any({Foo {1} 8})`,
+			func(is *assert.Is) { is.NotEqual(Any{"Foo", Foo{1}, 0}, Any{"Foo", Foo{1}, 8}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, tt.opts...)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestSame(t *testing.T) {
+	alice, bob := &point{1, 2}, &point{1, 2}
+	addr := func(p interface{}) string { return fmt.Sprintf("%p", p) }
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"Same", pass, ``,
+			func(is *assert.Is) { is.Same(alice, alice) }},
+		{"Same different objects", fail, "is.Same: " + addr(alice) + " != " + addr(bob) + " // equal but not same",
+			func(is *assert.Is) { is.Same(alice, bob) /* equal but not same */ }},
+		{"Same different types", fail, `is.Same: *is_test.point != *int`,
+			func(is *assert.Is) { is.Same(alice, new(int)) }},
+		{"Same not a pointer", fail, `is.Same: is_test.point({1 2}) is not a pointer`,
+			func(is *assert.Is) { is.Same(*alice, alice) }},
+		{"Same nil", fail, `is.Same: <nil> is not a pointer`,
+			func(is *assert.Is) { is.Same(alice, nil) }},
+		{"NotSame", pass, ``,
+			func(is *assert.Is) { is.NotSame(alice, bob) }},
+		{"NotSame different types", pass, ``,
+			func(is *assert.Is) { is.NotSame(alice, new(int)) }},
+		{"NotSame same object", fail, "is.NotSame: both are " + addr(alice) + " // shared",
+			func(is *assert.Is) { is.NotSame(alice, alice) /* shared */ }},
+		{"NotSame not a pointer", fail, `is.NotSame: []int([1]) is not a pointer`,
+			func(is *assert.Is) { is.NotSame(alice, []int{1}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNil(t *testing.T) {
+	prefix := "is.Nil: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil", pass, ``,
+			func(is *assert.Is) { is.Nil(nil) }},
+		{"nil pointer", pass, ``,
+			func(is *assert.Is) { is.Nil((*QueryError)(nil)) }},
+		{"nil map", pass, ``,
+			func(is *assert.Is) { is.Nil(map[string]int(nil)) }},
+		{"nil slice", pass, ``,
+			func(is *assert.Is) { is.Nil([]string(nil)) }},
+		{"nil channel", pass, ``,
+			func(is *assert.Is) { is.Nil((chan int)(nil)) }},
+		{"pointer", fail, prefix + `*is_test.QueryError(query: SELECT) is not nil`,
+			func(is *assert.Is) { is.Nil(&QueryError{"SELECT"}) }},
+		{"empty slice", fail, prefix + `[]string([]) is not nil`,
+			func(is *assert.Is) { is.Nil([]string{}) }},
+		{"non-nillable", fail, prefix + `int(0) is not nil // zero is not nil`,
+			func(is *assert.Is) { is.Nil(0) /* zero is not nil */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNotNil(t *testing.T) {
+	prefix := "is.NotNil: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"non-nil", pass, ``,
+			func(is *assert.Is) { is.NotNil(&QueryError{"SELECT"}) }},
+		{"non-nillable", pass, ``,
+			func(is *assert.Is) { is.NotNil(0) }},
+		{"nil", fail, prefix + `<nil>`,
+			func(is *assert.Is) { is.NotNil(nil) }},
+		{"nil pointer", fail, prefix + `*is_test.QueryError(<nil>)`,
+			func(is *assert.Is) { is.NotNil((*QueryError)(nil)) }},
+		{"nil map", fail, prefix + `map[string]int(map[]) // should be initialized`,
+			func(is *assert.Is) { is.NotNil(map[string]int(nil)) /* should be initialized */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestLen(t *testing.T) {
+	prefix := "is.Len: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"slice", pass, ``,
+			func(is *assert.Is) { is.Len([]int{1, 2, 3}, 3) }},
+		{"map", pass, ``,
+			func(is *assert.Is) { is.Len(map[string]int{"one": 1}, 1) }},
+		{"string", pass, ``,
+			func(is *assert.Is) { is.Len("four", 4) }},
+		{"array", pass, ``,
+			func(is *assert.Is) { is.Len([2]bool{}, 2) }},
+		{"channel", pass, ``,
+			func(is *assert.Is) {
+				ch := make(chan int, 2)
+				ch <- 1
+				is.Len(ch, 1)
+			}},
+		{"nil slice", pass, ``,
+			func(is *assert.Is) { is.Len([]int(nil), 0) }},
+		{"different length", fail, prefix + `len 3 != 5 // not enough`,
+			func(is *assert.Is) { is.Len([]int{1, 2, 3}, 5) /* not enough */ }},
+		{"no length", fail, prefix + `int(5) has no length`,
+			func(is *assert.Is) { is.Len(5, 5) }},
+		{"nil", fail, prefix + `<nil> has no length`,
+			func(is *assert.Is) { is.Len(nil, 0) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil", pass, ``,
+			func(is *assert.Is) { is.Empty(nil) }},
+		{"nil slice", pass, ``,
+			func(is *assert.Is) { is.Empty([]int(nil)) }},
+		{"empty slice", pass, ``,
+			func(is *assert.Is) { is.Empty([]int{}) }},
+		{"empty map", pass, ``,
+			func(is *assert.Is) { is.Empty(map[string]int{}) }},
+		{"empty string", pass, ``,
+			func(is *assert.Is) { is.Empty("") }},
+		{"empty channel", pass, ``,
+			func(is *assert.Is) { is.Empty(make(chan int, 1)) }},
+		{"zero number", pass, ``,
+			func(is *assert.Is) { is.Empty(0.0) }},
+		{"false", pass, ``,
+			func(is *assert.Is) { is.Empty(false) }},
+		{"nil pointer", pass, ``,
+			func(is *assert.Is) { is.Empty((*point)(nil)) }},
+		{"zero struct", pass, ``,
+			func(is *assert.Is) { is.Empty(point{}) }},
+		{"zero array", pass, ``,
+			func(is *assert.Is) { is.Empty([2]int{}) }},
+		{"slice", fail, `is.Empty: [1 2] is not empty // has elements`,
+			func(is *assert.Is) { is.Empty([]int{1, 2}) /* has elements */ }},
+		{"number", fail, `is.Empty: 5 is not empty`,
+			func(is *assert.Is) { is.Empty(5) }},
+		{"pointer to zero value", fail, `is.Empty: &{0 0} is not empty`,
+			func(is *assert.Is) { is.Empty(&point{}) }},
+		{"struct", fail, `is.Empty: {1 0} is not empty`,
+			func(is *assert.Is) { is.Empty(point{X: 1}) }},
+		{"array", fail, `is.Empty: [0 1] is not empty`,
+			func(is *assert.Is) { is.Empty([2]int{0, 1}) }},
+		{"NotEmpty", pass, ``,
+			func(is *assert.Is) { is.NotEmpty("hi") }},
+		{"NotEmpty pointer to zero value", pass, ``,
+			func(is *assert.Is) { is.NotEmpty(&point{}) }},
+		{"NotEmpty empty string", fail, `is.NotEmpty:  is empty // say something`,
+			func(is *assert.Is) { is.NotEmpty("") /* say something */ }},
+		{"NotEmpty nil", fail, `is.NotEmpty: <nil> is empty`,
+			func(is *assert.Is) { is.NotEmpty(nil) }},
+		{"NotEmpty empty map", fail, `is.NotEmpty: map[] is empty`,
+			func(is *assert.Is) { is.NotEmpty(map[string]int{}) }},
+		{"NotEmpty zero number", fail, `is.NotEmpty: 0 is empty`,
+			func(is *assert.Is) { is.NotEmpty(0) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestZero(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil", pass, ``,
+			func(is *assert.Is) { is.Zero(nil) }},
+		{"int", pass, ``,
+			func(is *assert.Is) { is.Zero(0) }},
+		{"struct", pass, ``,
+			func(is *assert.Is) { is.Zero(point{}) }},
+		{"nil pointer", pass, ``,
+			func(is *assert.Is) { is.Zero((*point)(nil)) }},
+		{"nil slice", pass, ``,
+			func(is *assert.Is) { is.Zero([]int(nil)) }},
+		{"not zero int", fail, `is.Zero: int(5) is not the zero value // she never calls`,
+			func(is *assert.Is) { is.Zero(5) /* she never calls */ }},
+		{"not zero struct", fail, `is.Zero: is_test.point({0 1}) is not the zero value`,
+			func(is *assert.Is) { is.Zero(point{Y: 1}) }},
+		{"pointer to zero value", fail, `is.Zero: *is_test.point(&{0 0}) is not the zero value`,
+			func(is *assert.Is) { is.Zero(&point{}) }},
+		{"empty slice", fail, `is.Zero: []int([]) is not the zero value`,
+			func(is *assert.Is) { is.Zero([]int{}) }},
+		{"NotZero", pass, ``,
+			func(is *assert.Is) { is.NotZero(5) }},
+		{"NotZero pointer", pass, ``,
+			func(is *assert.Is) { is.NotZero(&point{}) }},
+		{"NotZero zero int", fail, `is.NotZero: int(0) is the zero value // call me maybe`,
+			func(is *assert.Is) { is.NotZero(0) /* call me maybe */ }},
+		{"NotZero zero struct", fail, `is.NotZero: is_test.point({0 0}) is the zero value`,
+			func(is *assert.Is) { is.NotZero(point{}) }},
+		{"NotZero nil pointer", fail, `is.NotZero: *is_test.point(<nil>) is the zero value`,
+			func(is *assert.Is) { is.NotZero((*point)(nil)) }},
+		{"NotZero nil", fail, `is.NotZero: <nil> is the zero value`,
+			func(is *assert.Is) { is.NotZero(nil) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMapHasKey(t *testing.T) {
+	prefix := "is.MapHasKey: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"has key", pass, ``,
+			func(is *assert.Is) { is.MapHasKey(map[string]int{"foo": 1}, "foo") }},
+		{"zero value", pass, ``,
+			func(is *assert.Is) { is.MapHasKey(map[string]int{"foo": 0}, "foo") }},
+		{"nil key", pass, ``,
+			func(is *assert.Is) { is.MapHasKey(map[interface{}]int{nil: 1}, nil) }},
+		{"no key", fail, prefix + `map has no key "foo" // forgotten`,
+			func(is *assert.Is) { is.MapHasKey(map[string]int{"bar": 1}, "foo") /* forgotten */ }},
+		{"no int key", fail, prefix + `map has no key 2`,
+			func(is *assert.Is) { is.MapHasKey(map[int]string{1: "one"}, 2) }},
+		{"nil map", fail, prefix + `map has no key "foo"`,
+			func(is *assert.Is) { is.MapHasKey(map[string]int(nil), "foo") }},
+		{"key type", fail, prefix + `int(1) is not of the key type string`,
+			func(is *assert.Is) { is.MapHasKey(map[string]int{}, 1) }},
+		{"not a map", fail, prefix + `[]string([foo]) is not a map`,
+			func(is *assert.Is) { is.MapHasKey([]string{"foo"}, "foo") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMapValue(t *testing.T) {
+	prefix := "is.MapValue: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.MapValue(map[string]int{"foo": 1}, "foo", 1) }},
+		{"deep equal", pass, ``,
+			func(is *assert.Is) { is.MapValue(map[int][]string{1: {"a"}}, 1, []string{"a"}) }},
+		{"wrong value", fail, prefix + `m["foo"] = 1, want 2 // off by one`,
+			func(is *assert.Is) { is.MapValue(map[string]int{"foo": 1}, "foo", 2) /* off by one */ }},
+		{"wrong type", fail, prefix + `m["foo"] = int(1), want int64(1)`,
+			func(is *assert.Is) { is.MapValue(map[string]int{"foo": 1}, "foo", int64(1)) }},
+		{"no key", fail, prefix + `map has no key "foo"`,
+			func(is *assert.Is) { is.MapValue(map[string]int{"bar": 0}, "foo", 0) }},
+		{"not a map", fail, prefix + `<nil> is not a map`,
+			func(is *assert.Is) { is.MapValue(nil, "foo", 0) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestElementsMatch(t *testing.T) {
+	prefix := "is.ElementsMatch: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"same order", pass, ``,
+			func(is *assert.Is) { is.ElementsMatch([]int{1, 2, 3}, []int{1, 2, 3}) }},
+		{"different order", pass, ``,
+			func(is *assert.Is) { is.ElementsMatch([]string{"b", "a"}, []string{"a", "b"}) }},
+		{"slice and array", pass, ``,
+			func(is *assert.Is) { is.ElementsMatch([]int{2, 1}, [2]int{1, 2}) }},
+		{"empty", pass, ``,
+			func(is *assert.Is) { is.ElementsMatch([]int(nil), []int{}) }},
+		{"extra and missing", fail, prefix + `extra in a: [3], missing from a: [5] // five`,
+			func(is *assert.Is) { is.ElementsMatch([]int{1, 3}, []int{5, 1}) /* five */ }},
+		{"duplicates", fail, prefix + `extra in a: [1]`,
+			func(is *assert.Is) { is.ElementsMatch([]int{1, 1, 2}, []int{2, 1}) }},
+		{"missing", fail, prefix + `missing from a: [{1 2}]`,
+			func(is *assert.Is) { is.ElementsMatch([]point{}, []point{{1, 2}}) }},
+		{"not a slice", fail, prefix + `int(1) is not a slice or an array`,
+			func(is *assert.Is) { is.ElementsMatch([]int{1}, 1) }},
+		{"nil", fail, prefix + `<nil> is not a slice or an array`,
+			func(is *assert.Is) { is.ElementsMatch(nil, []int{1}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestSubset(t *testing.T) {
+	prefix := "is.Subset: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"subset", pass, ``,
+			func(is *assert.Is) { is.Subset([]string{"a", "b", "c"}, []string{"c", "a"}) }},
+		{"empty part", pass, ``,
+			func(is *assert.Is) { is.Subset([]int{1}, []int(nil)) }},
+		{"array", pass, ``,
+			func(is *assert.Is) { is.Subset([3]int{1, 2, 3}, []int{2}) }},
+		{"map", pass, ``,
+			func(is *assert.Is) { is.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2}) }},
+		{"missing", fail, prefix + `[a b] is missing [c] // c`,
+			func(is *assert.Is) { is.Subset([]string{"a", "b"}, []string{"a", "c"}) /* c */ }},
+		{"missing key", fail, prefix + `map[a:1] is missing map[b:2]`,
+			func(is *assert.Is) { is.Subset(map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}) }},
+		{"different value", fail, prefix + `map[a:1] is missing map[a:2]`,
+			func(is *assert.Is) { is.Subset(map[string]int{"a": 1}, map[string]int{"a": 2}) }},
+		{"different key type", fail, prefix + `map[a:1] is missing map[1:1]`,
+			func(is *assert.Is) { is.Subset(map[string]int{"a": 1}, map[int]int{1: 1}) }},
+		{"different kind", fail, prefix + `[]string and map[string]int are not the same kind of collection`,
+			func(is *assert.Is) { is.Subset([]string{"a"}, map[string]int{"a": 1}) }},
+		{"not a collection", fail, prefix + `int and []int are not the same kind of collection`,
+			func(is *assert.Is) { is.Subset(1, []int{1}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestSuperset(t *testing.T) {
+	prefix := "is.Superset: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"superset", pass, ``,
+			func(is *assert.Is) { is.Superset([]int{1, 2, 3}, []int{3}) }},
+		{"map", pass, ``,
+			func(is *assert.Is) { is.Superset(map[string]bool{"a": true}, map[string]bool{}) }},
+		{"missing", fail, prefix + `[1 2] is not a superset of [2 3], missing [3] // three`,
+			func(is *assert.Is) { is.Superset([]int{1, 2}, []int{2, 3}) /* three */ }},
+		{"different kind", fail, prefix + `map[string]int and []string are not the same kind of collection`,
+			func(is *assert.Is) { is.Superset(map[string]int{"a": 1}, []string{"a"}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestIsType(t *testing.T) {
+	prefix := "is.IsType: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"same type", pass, ``,
+			func(is *assert.Is) { is.IsType(&QueryError{}, error(&QueryError{"SELECT"})) }},
+		{"both nil", pass, ``,
+			func(is *assert.Is) { is.IsType(nil, nil) }},
+		{"different type", fail, prefix + `expected *is_test.QueryError, got *errors.errorString // not a query`,
+			func(is *assert.Is) { is.IsType(&QueryError{}, errWrong) /* not a query */ }},
+		{"pointer and value", fail, prefix + `expected *is_test.QueryError, got is_test.QueryError`,
+			func(is *assert.Is) { is.IsType(&QueryError{}, QueryError{}) }},
+		{"nil", fail, prefix + `expected int, got <nil>`,
+			func(is *assert.Is) { is.IsType(0, nil) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errWrong }
+
+func TestLinesElementsMatch(t *testing.T) {
+	prefix := "is.LinesElementsMatch: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"same order", pass, ``,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("a\nb"), []string{"a", "b"}) }},
+		{"reordered", pass, ``,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("b\na\n"), []string{"a", "b"}) }},
+		{"carriage return", pass, ``,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("a\r\nb\r\n"), []string{"b", "a"}) }},
+		{"empty", pass, ``,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader(""), nil) }},
+		{"empty line", fail, prefix + `missing from got: [""]`,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("a\n"), []string{"a", ""}) }},
+		{"missing line", fail, prefix + `missing from got: ["c"] // lost`,
+			func(is *assert.Is) {
+				is.LinesElementsMatch(strings.NewReader("b\na\n"), []string{"a", "b", "c"}) /* lost */
+			}},
+		{"extra and missing", fail, prefix + `extra in got: ["a"], missing from got: ["c"]`,
+			func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("a\na\nb"), []string{"a", "b", "c"}) }},
+		{"read error", fail, prefix + `reading got: something's wrong`,
+			func(is *assert.Is) { is.LinesElementsMatch(errReader{}, nil) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEqualGroupedUnordered(t *testing.T) {
+	prefix := "is.EqualGroupedUnordered: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.EqualGroupedUnordered([][]int{{1, 2}, {3}}, [][]int{{1, 2}, {3}}) }},
+		{"inner reordering", pass, ``,
+			func(is *assert.Is) { is.EqualGroupedUnordered([][]int{{1, 2}, {3, 4}}, [][]int{{2, 1}, {4, 3}}) }},
+		{"outer reordering", fail, prefix + `group 0: [1 2] != [3 4]`,
+			func(is *assert.Is) { is.EqualGroupedUnordered([][]int{{1, 2}, {3, 4}}, [][]int{{3, 4}, {1, 2}}) }},
+		{"duplicates", fail, prefix + `group 0: [1 1 2] != [1 2 2] // duplicates matter`,
+			func(is *assert.Is) {
+				is.EqualGroupedUnordered([][]int{{1, 1, 2}}, [][]int{{1, 2, 2}}) /* duplicates matter */
+			}},
+		{"different number of groups", fail, prefix + `1 groups != 2 groups`,
+			func(is *assert.Is) { is.EqualGroupedUnordered([][]int{{1}}, [][]int{{1}, {2}}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestTypeOneOf(t *testing.T) {
+	prefix := "is.TypeOneOf: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"matching type", pass, ``,
+			func(is *assert.Is) { is.TypeOneOf(&QueryError{}, "", &QueryError{}, 0) }},
+		{"matching nil", pass, ``,
+			func(is *assert.Is) { is.TypeOneOf(nil, "", nil) }},
+		{"non-matching type", fail, prefix + `got *errors.errorString, want one of [*is_test.QueryError, string] // query error`,
+			func(is *assert.Is) { is.TypeOneOf(errWrong, &QueryError{}, "") /* query error */ }},
+		{"non-matching nil", fail, prefix + `got <nil>, want one of [*is_test.QueryError]`,
+			func(is *assert.Is) { is.TypeOneOf(nil, &QueryError{}) }},
+		{"typed nil", fail, prefix + `got *is_test.QueryError, want one of [<nil>]`,
+			func(is *assert.Is) { is.TypeOneOf((*QueryError)(nil), nil) }},
+		{"no types", fail, prefix + `got int, want one of []`,
+			func(is *assert.Is) { is.TypeOneOf(1) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestGraphEqual(t *testing.T) {
+	prefix := "is.GraphEqual: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) {
+				is.GraphEqual(map[string][]string{"a": {"b"}, "b": nil}, map[string][]string{"a": {"b"}, "b": nil})
+			}},
+		{"neighbor reordering", pass, ``,
+			func(is *assert.Is) {
+				is.GraphEqual(map[string][]string{"a": {"b", "c"}}, map[string][]string{"a": {"c", "b", "c"}})
+			}},
+		{"edge difference", fail, prefix + `node "a": edges [b c] != [b d] // dependency graph`,
+			func(is *assert.Is) {
+				is.GraphEqual(map[string][]string{"a": {"c", "b"}}, map[string][]string{"a": {"b", "d"}}) // dependency graph
+			}},
+		{"node difference", fail, prefix + `node "a": missing != [b]; node "b": [] != missing`,
+			func(is *assert.Is) {
+				is.GraphEqual(map[string][]string{"b": nil}, map[string][]string{"a": {"b"}})
+			}},
+		{"multiple differences", fail, prefix + `node "a": edges [b] != [c]; node "c": edges [] != [a]`,
+			func(is *assert.Is) {
+				is.GraphEqual(map[string][]string{"a": {"b"}, "b": {}, "c": {}}, map[string][]string{"a": {"c"}, "b": {}, "c": {"a"}})
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestImplements(t *testing.T) {
+	prefix := "is.Implements: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"implements", pass, ``,
+			func(is *assert.Is) { is.Implements((*io.Reader)(nil), strings.NewReader("")) }},
+		{"pointer receiver", pass, ``,
+			func(is *assert.Is) { is.Implements((*error)(nil), &QueryError{}) }},
+		{"nil pointer", pass, ``,
+			func(is *assert.Is) { is.Implements((*error)(nil), (*QueryError)(nil)) }},
+		{"not implements", fail, prefix + `is_test.QueryError does not implement error // pointer receiver`,
+			func(is *assert.Is) { is.Implements((*error)(nil), QueryError{}) /* pointer receiver */ }},
+		{"nil", fail, prefix + `<nil> does not implement io.Reader`,
+			func(is *assert.Is) { is.Implements((*io.Reader)(nil), nil) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("not an interface", func(t *testing.T) {
+		defer func() {
+			errMsg := "is: iface must be a pointer to an interface"
+			if err := recover(); err != errMsg {
+				t.Errorf("%q != %q", err, errMsg)
+			}
+		}()
+		is.New(new(mockT)).Implements(new(int), 1)
+	})
+}
+
+func TestEqualStripANSI(t *testing.T) {
+	prefix := "is.EqualStripANSI: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"plain", pass, ``,
+			func(is *assert.Is) { is.EqualStripANSI("hello", "hello") }},
+		{"colored", pass, ``,
+			func(is *assert.Is) { is.EqualStripANSI("\x1b[1;31mhello\x1b[0m world", "hello world") }},
+		{"both colored", pass, ``,
+			func(is *assert.Is) { is.EqualStripANSI("\x1b[32mok\x1b[m", "\x1b[34mok\x1b[39m") }},
+		{"cursor movement", pass, ``,
+			func(is *assert.Is) { is.EqualStripANSI("\x1b[2K\x1b[1Gdone", "done") }},
+		{"content difference", fail, prefix + `hello != hello world // colored greeting`,
+			func(is *assert.Is) { is.EqualStripANSI("\x1b[31mhello\x1b[0m", "hello world") /* colored greeting */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestWithinDuration(t *testing.T) {
+	prefix := "is.WithinDuration: "
+	base := time.Date(2023, 2, 14, 19, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.WithinDuration(base, base, 0) }},
+		{"within delta", pass, ``,
+			func(is *assert.Is) { is.WithinDuration(base.Add(time.Second), base, time.Second) }},
+		{"negative difference", pass, ``,
+			func(is *assert.Is) { is.WithinDuration(base, base.Add(time.Second), time.Second) }},
+		{"different location", pass, ``,
+			func(is *assert.Is) { is.WithinDuration(base.In(time.FixedZone("WIB", 7*60*60)), base, 0) }},
+		{"exceeded", fail, prefix + `2023-02-14T19:00:03Z and 2023-02-14T19:00:00Z differ by 3s, allowed 1s // late`,
+			func(is *assert.Is) { is.WithinDuration(base.Add(3*time.Second), base, time.Second) /* late */ }},
+		{"exceeded negative", fail, prefix + `2023-02-14T19:00:00Z and 2023-02-14T19:00:03Z differ by 3s, allowed 1s`,
+			func(is *assert.Is) { is.WithinDuration(base, base.Add(3*time.Second), time.Second) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestTimeFormatEqual(t *testing.T) {
+	prefix := "is.TimeFormatEqual: "
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.TimeFormatEqual("2024-01-02", "2006-01-02", date) }},
+		{"same instant in other zone", pass, ``,
+			func(is *assert.Is) {
+				is.TimeFormatEqual("2024-01-02T07:00:00+07:00", time.RFC3339, date)
+			}},
+		{"different", fail, prefix + `"2024-01-02" parses to 2024-01-02, want 2024-01-03 // tomorrow`,
+			func(is *assert.Is) {
+				is.TimeFormatEqual("2024-01-02", "2006-01-02", date.AddDate(0, 0, 1)) /* tomorrow */
+			}},
+		{"finer than layout", fail, prefix + `"2024-01-02" parses to 2024-01-02T00:00:00Z, want 2024-01-02T01:00:00Z`,
+			func(is *assert.Is) { is.TimeFormatEqual("2024-01-02", "2006-01-02", date.Add(time.Hour)) }},
+		{"unparseable", fail, prefix + `"tomorrow" doesn't parse with layout "2006-01-02": parsing time "tomorrow" as "2006-01-02": cannot parse "tomorrow" as "2006"`,
+			func(is *assert.Is) { is.TimeFormatEqual("tomorrow", "2006-01-02", date) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNullableTimeEqual(t *testing.T) {
+	prefix := "is.NullableTimeEqual: "
+	date := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	later := date.Add(3 * time.Second)
+	var zero time.Time
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"both nil", pass, ``,
+			func(is *assert.Is) { is.NullableTimeEqual(nil, nil, 0) }},
+		{"both set", pass, ``,
+			func(is *assert.Is) { is.NullableTimeEqual(&date, &date, 0) }},
+		{"both set within delta", pass, ``,
+			func(is *assert.Is) { is.NullableTimeEqual(&later, &date, 3*time.Second) }},
+		{"both set with negative difference", pass, ``,
+			func(is *assert.Is) { is.NullableTimeEqual(&date, &later, 3*time.Second) }},
+		{"both set beyond delta", fail, prefix + `got 2024-01-02T15:04:08Z, want 2024-01-02T15:04:05Z, differ by 3s, allowed 1s`,
+			func(is *assert.Is) { is.NullableTimeEqual(&later, &date, time.Second) }},
+		{"nil got", fail, prefix + `got <nil>, want 2024-01-02T15:04:05Z // soft deleted`,
+			func(is *assert.Is) { is.NullableTimeEqual(nil, &date, time.Second) /* soft deleted */ }},
+		{"zero value with nil", fail, prefix + `got 0001-01-01T00:00:00Z, want <nil>`,
+			func(is *assert.Is) { is.NullableTimeEqual(&zero, nil, time.Second) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestMapOrderEqual(t *testing.T) {
+	prefix := "is.MapOrderEqual: "
+	vals := map[string]interface{}{"a": 1, "b": "two"}
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"correct order", pass, ``,
+			func(is *assert.Is) {
+				is.MapOrderEqual([]string{"a", "b"}, vals, [][2]interface{}{{"a", 1}, {"b", "two"}})
+			}},
+		{"empty", pass, ``,
+			func(is *assert.Is) { is.MapOrderEqual(nil, nil, nil) }},
+		{"wrong order", fail, prefix + `key order [b a] != [a b] // sorted`,
+			func(is *assert.Is) {
+				is.MapOrderEqual([]string{"b", "a"}, vals, [][2]interface{}{{"a", 1}, {"b", "two"}}) // sorted
+			}},
+		{"wrong values", fail, prefix + `value of "a": 1 != 2; value of "b": two != 2`,
+			func(is *assert.Is) { is.MapOrderEqual([]string{"a", "b"}, vals, [][2]interface{}{{"a", 2}, {"b", 2}}) }},
+		{"wrong order and values", fail, prefix + `key order [a b] != [b a]; value of "a": 1 != 2`,
+			func(is *assert.Is) {
+				is.MapOrderEqual([]string{"a", "b"}, vals, [][2]interface{}{{"b", "two"}, {"a", 2}})
+			}},
+		{"missing key", fail, prefix + `key order [a b] != [a b c]; value of "c": missing != 3`,
+			func(is *assert.Is) {
+				is.MapOrderEqual([]string{"a", "b"}, vals, [][2]interface{}{{"a", 1}, {"b", "two"}, {"c", 3}})
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("missing key formatted", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.WithThousandsSeparator())
+		is.MapOrderEqual([]string{"a"}, map[string]interface{}{"a": 1}, [][2]interface{}{{"a", 1}, {"c", 1000000}})
+		if want := prefix + `key order [a] != [a c]; value of "c": missing != 1,000,000`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestFailf(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"Failf", fail, `is.Failf: unexpected answer "no" // be brave`,
+			func(is *assert.Is) { is.Failf("unexpected answer %q", "no") /* be brave */ }},
+		{"Failf without args", fail, `is.Failf: unreachable`,
+			func(is *assert.Is) { is.Failf("unreachable") }},
+		{"FailNowf", failNow, `is.FailNowf: no girlfriend for me // forever alone`,
+			func(is *assert.Is) { is.FailNowf("no girlfriend for %s", "me") /* forever alone */ }},
+		{"FailNowf relaxed", fail, `is.FailNowf: unreachable`,
+			func(is *assert.Is) { is.New(is.T, assert.Relaxed()).FailNowf("unreachable") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEqualError(t *testing.T) {
+	prefix := "is.EqualError: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.EqualError(errWrong, "something's wrong") }},
+		{"nil error", failNow, prefix + `<nil>`,
+			func(is *assert.Is) { is.EqualError(nil, "boom") }},
+		{"not equal", failNow, prefix + `"boom" != "bang" // exact message`,
+			func(is *assert.Is) { is.EqualError(errors.New("boom"), "bang") /* exact message */ }},
+		{"substring", failNow, prefix + `"something's wrong" != "wrong"`,
+			func(is *assert.Is) { is.EqualError(errWrong, "wrong") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNotErrorIs(t *testing.T) {
+	prefix := "is.NotErrorIs: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil error", pass, ``,
+			func(is *assert.Is) { is.NotErrorIs(nil, context.Canceled) }},
+		{"other error", pass, ``,
+			func(is *assert.Is) { is.NotErrorIs(errWrong, context.Canceled) }},
+		{"same error", fail, prefix + `error unexpectedly wraps context canceled // swallowed`,
+			func(is *assert.Is) { is.NotErrorIs(context.Canceled, context.Canceled) /* swallowed */ }},
+		{"wrapped error", fail, prefix + `error unexpectedly wraps something's wrong`,
+			func(is *assert.Is) { is.NotErrorIs(fmt.Errorf("query: %w", errWrong), errWrong) }},
+		{"joined error", fail, prefix + `error unexpectedly wraps context canceled`,
+			func(is *assert.Is) { is.NotErrorIs(errors.Join(errWrong, context.Canceled), context.Canceled) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNoError(t *testing.T) {
+	prefix := "is.NoError: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"no error", pass, ``,
+			func(is *assert.Is) { is.NoError(nil) }},
+		{"error", failNow, prefix + `something's wrong`,
+			func(is *assert.Is) { is.NoError(errWrong) }},
+		{"error with comment", failNow, prefix + `something's wrong // shouldn't be error`,
+			func(is *assert.Is) { is.NoError(errWrong) /* shouldn't be error*/ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("got: %s, want: %s", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestHasPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"HasPrefix", pass, ``,
+			func(is *assert.Is) { is.HasPrefix("https://example.com", "https://") }},
+		{"HasPrefix empty", pass, ``,
+			func(is *assert.Is) { is.HasPrefix("hello", "") }},
+		{"not HasPrefix", fail, `is.HasPrefix: "hello world" does not start with "goodbye" // greeting`,
+			func(is *assert.Is) { is.HasPrefix("hello world", "goodbye") /* greeting */ }},
+		{"HasSuffix", pass, ``,
+			func(is *assert.Is) { is.HasSuffix("/tmp/girl.txt", ".txt") }},
+		{"not HasSuffix", fail, `is.HasSuffix: "see you" does not end with "xoxo" // hugs and kisses`,
+			func(is *assert.Is) { is.HasSuffix("see you", "xoxo") /* hugs and kisses */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	prefix := "is.Error: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil error", failNow, prefix + `<nil>`,
+			func(is *assert.Is) { is.Error(nil) }},
+		{"nil error with comment", failNow, prefix + `<nil> // shouldn't be nil`,
+			func(is *assert.Is) { is.Error(nil) /* shouldn't be nil*/ }},
+		{"any error", pass, ``,
+			func(is *assert.Is) { is.Error(err1) }},
+		{"nil with expected error", failNow, prefix + `<nil>`,
+			func(is *assert.Is) { is.Error(nil, err1) }},
+		{"any error with true expected error", pass, ``,
+			func(is *assert.Is) { is.Error(err1, err1) }},
+		{"any error with multiple true expected error", pass, ``,
+			func(is *assert.Is) { is.Error(err2, err1, err2, err3) }},
+		{"any error with false expected error", failNow, prefix + `error 1 != error 2`,
+			func(is *assert.Is) { is.Error(err1, err2) }},
+		{"any error with multiple false expected error", failNow, prefix + `error 1 != one of the expected errors`,
+			func(is *assert.Is) { is.Error(err1, err2, err3) }},
+		{"joined errors with true expected error", pass, ``,
+			func(is *assert.Is) { is.Error(errors.Join(err1, err2), err2) }},
+		{"joined errors with false expected error", failNow, prefix + `[db closed; timeout] does not wrap context canceled`,
+			func(is *assert.Is) {
+				is.Error(errors.Join(errors.New("db closed"), errors.New("timeout")), context.Canceled)
+			}},
+		{"nested joined errors", failNow, prefix + `[error 1; error 2; error 3] does not wrap any of the expected errors // flattened`,
+			func(is *assert.Is) {
+				err := fmt.Errorf("query: %w", errors.Join(err1, errors.Join(err2, err3)))
+				is.Error(err, errWrong, context.Canceled) // flattened
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("got: %s, want: %s", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	prefix := "is.ErrorAs: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"pass", pass, ``,
+			func(is *assert.Is) {
+				var e *QueryError
+				is.ErrorAs(&QueryError{"SELECT column_name(s) FROM table_name"}, &e)
+			}},
+		{"fail", failNow, prefix + `err != **is_test.QueryError // it's something else`,
+			func(is *assert.Is) {
+				var e *QueryError
+				is.ErrorAs(errors.New("it's not query error"), &e) // it's something else
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestErrorContains(t *testing.T) {
+	prefix := "is.ErrorContains: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"contains", pass, ``,
+			func(is *assert.Is) { is.ErrorContains(errWrong, "wrong") }},
+		{"empty substring", pass, ``,
+			func(is *assert.Is) { is.ErrorContains(errWrong, "") }},
+		{"nil error", failNow, prefix + `<nil>`,
+			func(is *assert.Is) { is.ErrorContains(nil, "wrong") }},
+		{"does not contain", failNow, prefix + `"something's wrong" does not contain "network" // not a network error`,
+			func(is *assert.Is) { is.ErrorContains(errWrong, "network") /* not a network error */ }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestTrue(t *testing.T) {
+	prefix := "is.True: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"true", pass, ``,
+			func(is *assert.Is) { is.True(1 == 1) }},
+		{"false", fail, prefix + `1 == 2 // false`,
+			func(is *assert.Is) { is.True(1 == 2) /* false*/ }},
+		{"extra parentheses", fail, prefix + `(1 == 2) // comment`,
+			func(is *assert.Is) { is.True((1 == 2)) /* comment */ }},
+		{"new line", fail, prefix + `(1 == 2) && false`,
+			func(is *assert.Is) {
+				is.True((1 == 2) &&
+					false)
+			}},
+		{"multi line", fail, prefix + `(1 == 2) && false || false`,
+			func(is *assert.Is) {
+				is.True((1 == 2) &&
+					false ||
+					false)
+			}},
+		{"multi line with comment in first line", fail, prefix + `(1 == 2) && false || false // comment`,
+			func(is *assert.Is) {
+				is.True((1 == 2) && // comment
+					false ||
+					false)
+			}},
+		{"operand", fail, prefix + `money != 0 (money == 0) // broke`,
+			func(is *assert.Is) {
+				money := 0
+				is.True(money != 0, money) // broke
+			}},
+		{"both operands", fail, prefix + `money > price (money == 5, price == 10)`,
+			func(is *assert.Is) {
+				money, price := 5, 10
+				is.True(money > price, money, price)
+			}},
+		{"literal operand", fail, prefix + `len(s) == 3 (len(s) == 2)`,
+			func(is *assert.Is) {
+				s := "ab"
+				is.True(len(s) == 3, len(s), 3)
+			}},
+		{"non-comparison with operand", fail, prefix + `ok && done (false)`,
+			func(is *assert.Is) {
+				ok, done := true, false
+				is.True(ok && done, done)
+			}},
+		{"multi line with comment in non-first line", fail, prefix + `(1 == 2) && false || false // comment`,
+			func(is *assert.Is) {
+				is.True((1 == 2) &&
+					false || // comment
+					false)
+			}},
+		{"multi line with comment in first and non-first line", fail, prefix + `(1 == 2) && false || false // first`,
+			func(is *assert.Is) {
+				is.True((1 == 2) && // first
+					false || // second
+					false)
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestTrueSameLine(t *testing.T) {
+	t.Run("closures", func(t *testing.T) {
+		m := new(mockT)
+		is := is.New(m)
+		asserts := []func(){func() { is.True(1 == 2) }, func() { is.False(2 == 2) }, func() { is.True(3 == 4) }}
+
+		want := []string{`is.True: 1 == 2`, `is.False: 2 == 2`, `is.True: 3 == 4`}
+		for i, assert := range asserts {
+			assert()
+			if m.msg != want[i] {
+				t.Errorf("%q != %q", m.msg, want[i])
+			}
+		}
+	})
+
+	t.Run("nested closures", func(t *testing.T) {
+		m := new(mockT)
+		is := is.New(m)
+		f := func() func() { is.True(1 == 1); return func() { is.True(5 == 6) } }
+
+		f()()
+		if want := `is.True: 5 == 6`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("same function", func(t *testing.T) {
+		m := new(mockT)
+		is := is.New(m)
+
+		_ = is.True(1 == 1) && is.True(7 == 8)
+		if want := `is.True: one of: 1 == 1; 7 == 8`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestTruef(t *testing.T) {
+	prefix := "is.Truef: "
+	isAdult := func(age int) bool { return age >= 18 }
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"true", pass, ``,
+			func(is *assert.Is) { is.Truef(isAdult(18), "she is %d", 18) }},
+		{"false", fail, prefix + `she is 17 (isAdult(age)) // wait a year`,
+			func(is *assert.Is) {
+				age := 17
+				is.Truef(isAdult(age), "she is %d", age) // wait a year
+			}},
+		{"without args", fail, prefix + `computed (isAdult(1))`,
+			func(is *assert.Is) { is.Truef(isAdult(1), "computed") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestFalse(t *testing.T) {
+	prefix := "is.False: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"false", pass, ``,
+			func(is *assert.Is) { is.False(1 == 2) }},
+		{"true", fail, prefix + `1 == 1 // true`,
+			func(is *assert.Is) { is.False(1 == 1) /* true*/ }},
+		{"negation", fail, prefix + `!false`,
+			func(is *assert.Is) { is.False(!false) }},
+		{"operands", fail, prefix + `a == b (a == 1, b == 1)`,
+			func(is *assert.Is) {
+				a, b := 1, 1
+				is.False(a == b, a, b)
+			}},
+		{"multi line", fail, prefix + `(1 == 1) && true || false`,
+			func(is *assert.Is) {
+				is.False((1 == 1) &&
+					true ||
+					false)
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEventually(t *testing.T) {
+	prefix := "is.Eventually: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"true right away", pass, ``,
+			func(is *assert.Is) { is.Eventually(func() bool { return true }, time.Hour, time.Hour) }},
+		{"true eventually", pass, ``,
+			func(is *assert.Is) {
+				n := 0
+				is.Eventually(func() bool { n++; return n == 3 }, time.Second, time.Millisecond)
+			}},
+		{"never true", failNow, prefix + `condition not met within 20ms // timeout`,
+			func(is *assert.Is) {
+				is.Eventually(func() bool { return false }, 20*time.Millisecond, time.Millisecond) // timeout
+			}},
+		{"zero interval", failNow, prefix + `interval must be positive, got 0s`,
+			func(is *assert.Is) { is.Eventually(func() bool { return false }, 10*time.Millisecond, 0) }},
+		{"negative interval", failNow, prefix + `interval must be positive, got -1ms`,
+			func(is *assert.Is) { is.Eventually(func() bool { return true }, time.Second, -time.Millisecond) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNever(t *testing.T) {
+	prefix := "is.Never: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"never true", pass, ``,
+			func(is *assert.Is) { is.Never(func() bool { return false }, 20*time.Millisecond, time.Millisecond) }},
+		{"true right away", failNow, prefix + `condition became true after 0s // closed`,
+			func(is *assert.Is) { is.Never(func() bool { return true }, time.Hour, time.Hour) /* closed */ }},
+		{"zero interval", failNow, prefix + `interval must be positive, got 0s`,
+			func(is *assert.Is) { is.Never(func() bool { return false }, 10*time.Millisecond, 0) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := is.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("true eventually", func(t *testing.T) {
+		t.Parallel()
+		m := new(mockT)
+		is := is.New(m)
+		n := 0
+		is.Never(func() bool { n++; return n == 3 }, time.Second, time.Millisecond)
+
+		assertState(t, m.state, failNow)
+		if want := prefix + "condition became true after "; !strings.HasPrefix(m.msg, want) {
+			t.Errorf("%q doesn't start with %q", m.msg, want)
+		}
+	})
+}
+
+func TestPanic(t *testing.T) {
+	prefix := "is.Panic: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"panic", pass, ``,
+			func(is *assert.Is) {
+				panicFunc := func() { panic("i'm panic") }
+				is.Panic(panicFunc)
+			}},
+		{"not panic", fail, prefix + `the function is not panic`,
+			func(is *assert.Is) {
+				calmFunc := func() { _ = "i'm calm" }
+				is.Panic(calmFunc)
+			}},
+		{"not panic with comment", fail, prefix + `the function is not panic // with comment`,
+			func(is *assert.Is) {
+				calmFunc := func() { _ = "i'm calm" }
+				is.Panic(calmFunc) // with comment
+			}},
+		{"panic with true panic value", pass, ``,
+			func(is *assert.Is) {
+				panicFunc := func() { panic("i'm panic") }
+				is.Panic(panicFunc, "i'm panic", "is this panic")
+			}},
+		{"panic with false panic value", fail, prefix + `i'm panic != are you panic`,
+			func(is *assert.Is) {
+				panicFunc := func() { panic("i'm panic") }
+				is.Panic(panicFunc, "are you panic")
+			}},
+		{"panic with multiple false panic value", fail, prefix + `i'm panic != one of the expected panic values`,
+			func(is *assert.Is) {
+				panicFunc := func() { panic("i'm panic") }
+				is.Panic(panicFunc, "are you panic", "are you crazy")
+			}},
 	}
 
 	for _, tt := range tests {
-		// fix for-range local variable when using t.Parallel that reduces code coverage
-		// see: https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721
 		tt := tt
-		t.Run(tt.desc, func(t *testing.T) {
+		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			m := new(mockT)
 			is := is.New(m)
@@ -56,20 +2404,34 @@ func TestEqual(t *testing.T) {
 	}
 }
 
-func TestNoError(t *testing.T) {
-	prefix := "is.NoError: "
+func TestPanicAs(t *testing.T) {
+	prefix := "is.PanicAs: "
 	tests := []struct {
 		name  string
 		state failState
 		msg   string
 		f     func(is *assert.Is)
 	}{
-		{"no error", pass, ``,
-			func(is *assert.Is) { is.NoError(nil) }},
-		{"error", failNow, prefix + `something's wrong`,
-			func(is *assert.Is) { is.NoError(errWrong) }},
-		{"error with comment", failNow, prefix + `something's wrong // shouldn't be error`,
-			func(is *assert.Is) { is.NoError(errWrong) /* shouldn't be error*/ }},
+		{"assignable", pass, ``,
+			func(is *assert.Is) {
+				var qe *QueryError
+				is.PanicAs(func() { panic(&QueryError{"SELECT"}) }, &qe)
+			}},
+		{"interface", pass, ``,
+			func(is *assert.Is) {
+				var err error
+				is.PanicAs(func() { panic(&QueryError{"SELECT"}) }, &err)
+			}},
+		{"not assignable", fail, prefix + `panic value string is not *is_test.QueryError // not an error`,
+			func(is *assert.Is) {
+				var qe *QueryError
+				is.PanicAs(func() { panic("SELECT") }, &qe) // not an error
+			}},
+		{"not panic", fail, prefix + `the function is not panic`,
+			func(is *assert.Is) {
+				var qe *QueryError
+				is.PanicAs(func() {}, &qe)
+			}},
 	}
 
 	for _, tt := range tests {
@@ -82,36 +2444,32 @@ func TestNoError(t *testing.T) {
 
 			assertState(t, m.state, tt.state)
 			if m.msg != tt.msg {
-				t.Errorf("got: %s, want: %s", m.msg, tt.msg)
+				t.Errorf("%q != %q", m.msg, tt.msg)
 			}
 		})
 	}
 }
 
-func TestError(t *testing.T) {
-	prefix := "is.Error: "
+func TestPanicMatches(t *testing.T) {
+	prefix := "is.PanicMatches: "
 	tests := []struct {
 		name  string
 		state failState
 		msg   string
 		f     func(is *assert.Is)
 	}{
-		{"nil error", failNow, prefix + `<nil>`,
-			func(is *assert.Is) { is.Error(nil) }},
-		{"nil error with comment", failNow, prefix + `<nil> // shouldn't be nil`,
-			func(is *assert.Is) { is.Error(nil) /* shouldn't be nil*/ }},
-		{"any error", pass, ``,
-			func(is *assert.Is) { is.Error(err1) }},
-		{"nil with expected error", failNow, prefix + `<nil>`,
-			func(is *assert.Is) { is.Error(nil, err1) }},
-		{"any error with true expected error", pass, ``,
-			func(is *assert.Is) { is.Error(err1, err1) }},
-		{"any error with multiple true expected error", pass, ``,
-			func(is *assert.Is) { is.Error(err2, err1, err2, err3) }},
-		{"any error with false expected error", failNow, prefix + `error 1 != error 2`,
-			func(is *assert.Is) { is.Error(err1, err2) }},
-		{"any error with multiple false expected error", failNow, prefix + `error 1 != one of the expected errors`,
-			func(is *assert.Is) { is.Error(err1, err2, err3) }},
+		{"match", pass, ``,
+			func(is *assert.Is) { is.PanicMatches(func() { panic("index 7 out of range") }, `index \d+`) }},
+		{"error value", pass, ``,
+			func(is *assert.Is) { is.PanicMatches(func() { panic(&QueryError{"SELECT"}) }, "^query: ") }},
+		{"not match", fail, prefix + `"index 7 out of range" does not match "out of bounds" // bounds`,
+			func(is *assert.Is) {
+				is.PanicMatches(func() { panic("index 7 out of range") }, "out of bounds") // bounds
+			}},
+		{"not panic", fail, prefix + `the function is not panic`,
+			func(is *assert.Is) { is.PanicMatches(func() {}, "") }},
+		{"invalid pattern", failNow, prefix + `invalid pattern "(": error parsing regexp: missing closing ): ` + "`(`",
+			func(is *assert.Is) { is.PanicMatches(func() { panic("not called") }, "(") }},
 	}
 
 	for _, tt := range tests {
@@ -124,30 +2482,79 @@ func TestError(t *testing.T) {
 
 			assertState(t, m.state, tt.state)
 			if m.msg != tt.msg {
-				t.Errorf("got: %s, want: %s", m.msg, tt.msg)
+				t.Errorf("%q != %q", m.msg, tt.msg)
 			}
 		})
 	}
 }
 
-func TestErrorAs(t *testing.T) {
-	prefix := "is.ErrorAs: "
+func TestPanicAsTarget(t *testing.T) {
+	m := new(mockT)
+	is := is.New(m)
+	var qe *QueryError
+	is.PanicAs(func() { panic(&QueryError{"SELECT"}) }, &qe)
+
+	if qe == nil || qe.Query != "SELECT" {
+		t.Errorf("%v != %v", qe, &QueryError{"SELECT"})
+	}
+
+	defer func() {
+		errMsg := "is: target must be a non-nil pointer"
+		if err := recover(); err != errMsg {
+			t.Errorf("%q != %q", err, errMsg)
+		}
+	}()
+	is.PanicAs(func() {}, (*QueryError)(nil))
+}
+
+func TestReceives(t *testing.T) {
+	buffered := func(v interface{}) chan interface{} {
+		ch := make(chan interface{}, 1)
+		ch <- v
+		return ch
+	}
+	closed := make(chan int)
+	close(closed)
+
 	tests := []struct {
 		name  string
 		state failState
 		msg   string
 		f     func(is *assert.Is)
 	}{
-		{"pass", pass, ``,
+		{"buffered", pass, ``,
+			func(is *assert.Is) { is.Receives(buffered([]int{5}), []int{5}, time.Second) }},
+		{"unbuffered", pass, ``,
 			func(is *assert.Is) {
-				var e *QueryError
-				is.ErrorAs(&QueryError{"SELECT column_name(s) FROM table_name"}, &e)
+				ch := make(chan string)
+				go func() { ch <- "hi too" }()
+				is.Receives(ch, "hi too", time.Second)
 			}},
-		{"fail", failNow, prefix + `err != **is_test.QueryError // it's something else`,
+		{"receive-only", pass, ``,
 			func(is *assert.Is) {
-				var e *QueryError
-				is.ErrorAs(errors.New("it's not query error"), &e) // it's something else
+				var ch <-chan interface{} = buffered(5)
+				is.Receives(ch, 5, time.Second)
+			}},
+		{"wrong value", failNow, `is.Receives: got 3, want 5 // wrong reply`,
+			func(is *assert.Is) { is.Receives(buffered(3), 5, time.Second) /* wrong reply */ }},
+		{"different type", failNow, `is.Receives: got 5, want 5`,
+			func(is *assert.Is) { is.Receives(buffered(int64(5)), 5, time.Second) }},
+		{"timeout", failNow, `is.Receives: no value on channel within 10ms`,
+			func(is *assert.Is) { is.Receives(make(chan int), 5, 10*time.Millisecond) }},
+		{"closed", failNow, `is.Receives: channel closed, want 5`,
+			func(is *assert.Is) { is.Receives(closed, 5, time.Second) }},
+		{"Closed", pass, ``,
+			func(is *assert.Is) { is.Closed(closed, time.Second) }},
+		{"Closed later", pass, ``,
+			func(is *assert.Is) {
+				ch := make(chan struct{})
+				go close(ch)
+				is.Closed(ch, time.Second)
 			}},
+		{"Closed receives value", failNow, `is.Closed: received 3, the channel is not closed`,
+			func(is *assert.Is) { is.Closed(buffered(3), time.Second) }},
+		{"Closed timeout", failNow, `is.Closed: channel not closed within 10ms // let it go`,
+			func(is *assert.Is) { is.Closed(make(chan int), 10*time.Millisecond) /* let it go */ }},
 	}
 
 	for _, tt := range tests {
@@ -164,45 +2571,102 @@ func TestErrorAs(t *testing.T) {
 			}
 		})
 	}
+
+	panics := []struct {
+		name   string
+		errMsg string
+		f      func(is *assert.Is)
+	}{
+		{"not a channel", "is: int is not a receivable channel",
+			func(is *assert.Is) { is.Receives(5, 5, time.Second) }},
+		{"nil", "is: <nil> is not a receivable channel",
+			func(is *assert.Is) { is.Closed(nil, time.Second) }},
+		{"send-only channel", "is: chan<- int is not a receivable channel",
+			func(is *assert.Is) { is.Closed(make(chan<- int), time.Second) }},
+	}
+
+	for _, tt := range panics {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if err := recover(); err != tt.errMsg {
+					t.Errorf("%q != %q", err, tt.errMsg)
+				}
+			}()
+
+			tt.f(is.New(new(mockT)))
+		})
+	}
 }
 
-func TestTrue(t *testing.T) {
-	prefix := "is.True: "
+func TestNoGoroutineLeak(t *testing.T) {
+	t.Run("no goroutine", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.NoGoroutineLeak(func() {})
+		assertState(t, m.state, pass)
+	})
+
+	t.Run("cleaned up", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.NoGoroutineLeak(func() {
+			done := make(chan struct{})
+			go func() { close(done) }()
+			<-done
+		})
+		assertState(t, m.state, pass)
+	})
+
+	t.Run("exits shortly after", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.NoGoroutineLeak(func() {
+			go time.Sleep(50 * time.Millisecond)
+		})
+		assertState(t, m.state, pass)
+	})
+
+	t.Run("leaked", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		release := make(chan struct{})
+		defer close(release)
+		is.NoGoroutineLeak(func() {
+			go func() { <-release }()
+		}) // forgot to stop
+
+		assertState(t, m.state, fail)
+		lines := strings.Split(m.msg, "\n")
+		if want := "is.NoGoroutineLeak: 1 leaked goroutines // forgot to stop"; lines[0] != want {
+			t.Errorf("%q != %q", lines[0], want)
+		}
+		if !strings.HasPrefix(lines[1], "\tgoroutine ") || !strings.Contains(m.msg, "TestNoGoroutineLeak") {
+			t.Errorf("%q has no stack of the leaked goroutine", m.msg)
+		}
+	})
+}
+
+func TestReturn(t *testing.T) {
 	tests := []struct {
 		name  string
 		state failState
-		msg   string
-		f     func(is *assert.Is)
+		f     func(is *assert.Is) bool
 	}{
-		{"true", pass, ``,
-			func(is *assert.Is) { is.True(1 == 1) }},
-		{"false", fail, prefix + `1 == 2 // false`,
-			func(is *assert.Is) { is.True(1 == 2) /* false*/ }},
-		{"extra parentheses", fail, prefix + `(1 == 2) // comment`,
-			func(is *assert.Is) { is.True((1 == 2)) /* comment */ }},
-		{"new line", fail, prefix + `(1 == 2) && false`,
-			func(is *assert.Is) {
-				is.True((1 == 2) &&
-					false)
-			}},
-		{"multi line", fail, prefix + `(1 == 2) && false || false`,
-			func(is *assert.Is) {
-				is.True((1 == 2) &&
-					false ||
-					false)
-			}},
-		{"multi line with comment in first line", fail, prefix + `(1 == 2) && false || false // comment`,
-			func(is *assert.Is) {
-				is.True((1 == 2) && // comment
-					false ||
-					false)
-			}},
-		{"multi line with comment in non-first line", fail, prefix + `(1 == 2) && false || false`,
-			func(is *assert.Is) {
-				is.True((1 == 2) &&
-					false || // cannot be printed
-					false)
-			}},
+		{"is.Equal pass", pass, func(is *assert.Is) bool { return is.Equal(1, 1) }},
+		{"is.Equal fail", fail, func(is *assert.Is) bool { return is.Equal(1, 2) }},
+		{"is.Len fail", fail, func(is *assert.Is) bool { return is.Len([]int{1}, 2) }},
+		{"is.True pass", pass, func(is *assert.Is) bool { return is.True(true) }},
+		{"is.True fail", fail, func(is *assert.Is) bool { return is.True(false) }},
+		{"is.NoError pass", pass, func(is *assert.Is) bool { return is.NoError(nil) }},
+		{"is.NoError fail", failNow, func(is *assert.Is) bool { return is.NoError(errors.New("err")) }},
+		{"is.Error fail", failNow, func(is *assert.Is) bool { return is.Error(nil) }},
+		{"is.Panic pass", pass, func(is *assert.Is) bool { return is.Panic(func() { panic("panic") }) }},
+		{"is.Panic fail", fail, func(is *assert.Is) bool { return is.Panic(func() {}) }},
+		{"is.PanicMatches fail", failNow, func(is *assert.Is) bool { return is.PanicMatches(func() {}, "(") }},
+		{"assert.Contains pass", pass, func(is *assert.Is) bool { return assert.Contains(is, []int{1, 2}, 2) }},
+		{"assert.Contains fail", fail, func(is *assert.Is) bool { return assert.Contains(is, []int{1, 2}, 3) }},
+		{"assert.Between fail", fail, func(is *assert.Is) bool { return assert.Between(is, 4, 1, 3) }},
 	}
 
 	for _, tt := range tests {
@@ -210,68 +2674,53 @@ func TestTrue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			m := new(mockT)
-			is := is.New(m)
-			tt.f(is)
-
+			is := assert.New(m)
+			ok := tt.f(is)
 			assertState(t, m.state, tt.state)
-			if m.msg != tt.msg {
-				t.Errorf("%q != %q", m.msg, tt.msg)
+			if want := tt.state == pass; ok != want {
+				t.Errorf("returned %t, want %t", ok, want)
 			}
 		})
 	}
 }
 
-func TestPanic(t *testing.T) {
-	prefix := "is.Panic: "
-	tests := []struct {
-		name  string
-		state failState
-		msg   string
-		f     func(is *assert.Is)
-	}{
-		{"panic", pass, ``,
-			func(is *assert.Is) {
-				panicFunc := func() { panic("i'm panic") }
-				is.Panic(panicFunc)
-			}},
-		{"not panic", fail, prefix + `the function is not panic`,
-			func(is *assert.Is) {
-				calmFunc := func() { _ = "i'm calm" }
-				is.Panic(calmFunc)
-			}},
-		{"not panic with comment", fail, prefix + `the function is not panic // with comment`,
-			func(is *assert.Is) {
-				calmFunc := func() { _ = "i'm calm" }
-				is.Panic(calmFunc) // with comment
-			}},
-		{"panic with true panic value", pass, ``,
-			func(is *assert.Is) {
-				panicFunc := func() { panic("i'm panic") }
-				is.Panic(panicFunc, "i'm panic", "is this panic")
-			}},
-		{"panic with false panic value", fail, prefix + `i'm panic != are you panic`,
-			func(is *assert.Is) {
-				panicFunc := func() { panic("i'm panic") }
-				is.Panic(panicFunc, "are you panic")
-			}},
-		{"panic with multiple false panic value", fail, prefix + `i'm panic != one of the expected panic values`,
-			func(is *assert.Is) {
-				panicFunc := func() { panic("i'm panic") }
-				is.Panic(panicFunc, "are you panic", "are you crazy")
-			}},
+func TestDebugCaller(t *testing.T) {
+	m := new(mockT)
+	is := is.New(m)
+	is.DebugCaller()
+
+	lines := strings.Split(m.msg, "\n")
+	if len(lines) != 8 || lines[0] != "is.DebugCaller:" {
+		t.Fatalf("%q is not the frames of 7 skip levels", m.msg)
 	}
 
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
+	want := []string{
+		"skip 0: util.go:",
+		"skip 1: util.go:",
+		"skip 2: is.go:",
+		"skip 3: is_test.go:",
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(lines[i+1], "\t"+w) {
+			t.Errorf("%q doesn't start with %q", lines[i+1], "\t"+w)
+		}
+	}
+	if !strings.HasSuffix(lines[4], ".TestDebugCaller") {
+		t.Errorf("%q isn't the frame of the test", lines[4])
+	}
+	assertState(t, m.state, pass)
+}
+
+func TestParallel(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		t.Run("True", func(t *testing.T) {
 			t.Parallel()
 			m := new(mockT)
-			is := is.New(m)
-			tt.f(is)
+			is := assert.New(m)
+			is.True(1 == 2) // parallel
 
-			assertState(t, m.state, tt.state)
-			if m.msg != tt.msg {
-				t.Errorf("%q != %q", m.msg, tt.msg)
+			if want := "is.True: 1 == 2 // parallel"; m.msg != want {
+				t.Errorf("%q != %q", m.msg, want)
 			}
 		})
 	}
@@ -284,14 +2733,73 @@ func TestLine(t *testing.T) {
 		f    func(is *assert.Is)
 	}{
 		{"Equal", 2, func(is *assert.Is) { is.Equal(1, 2) }},
+		{"NotEqual", 2, func(is *assert.Is) { is.NotEqual(1, 1) }},
+		{"EqualValues", 2, func(is *assert.Is) { is.EqualValues(1, 2) }},
+		{"Same", 2, func(is *assert.Is) { is.Same(1, 2) }},
+		{"NotSame", 2, func(is *assert.Is) { is.NotSame(1, 2) }},
+		{"Nil", 2, func(is *assert.Is) { is.Nil(0) }},
+		{"NotNil", 2, func(is *assert.Is) { is.NotNil(nil) }},
+		{"Len", 2, func(is *assert.Is) { is.Len("", 1) }},
+		{"Empty", 2, func(is *assert.Is) { is.Empty(1) }},
+		{"NotEmpty", 2, func(is *assert.Is) { is.NotEmpty(0) }},
+		{"Zero", 2, func(is *assert.Is) { is.Zero(1) }},
+		{"NotZero", 2, func(is *assert.Is) { is.NotZero(0) }},
+		{"MapHasKey", 2, func(is *assert.Is) { is.MapHasKey(map[string]int{}, "a") }},
+		{"MapValue", 2, func(is *assert.Is) { is.MapValue(map[string]int{"a": 1}, "a", 2) }},
+		{"TypeOneOf", 2, func(is *assert.Is) { is.TypeOneOf(1) }},
+		{"Implements", 2, func(is *assert.Is) { is.Implements((*error)(nil), 1) }},
+		{"EqualStripANSI", 2, func(is *assert.Is) { is.EqualStripANSI("a", "b") }},
+		{"WithinDuration", 2, func(is *assert.Is) { is.WithinDuration(time.Time{}, time.Now(), 0) }},
+		{"TimeFormatEqual", 2, func(is *assert.Is) { is.TimeFormatEqual("", "", time.Now()) }},
+		{"NullableTimeEqual", 2, func(is *assert.Is) { is.NullableTimeEqual(nil, &time.Time{}, 0) }},
+		{"MapOrderEqual", 2, func(is *assert.Is) { is.MapOrderEqual([]string{"a"}, nil, nil) }},
+		{"GraphEqual", 2, func(is *assert.Is) { is.GraphEqual(nil, map[string][]string{"a": nil}) }},
+		{"ElementsMatch", 2, func(is *assert.Is) { is.ElementsMatch(nil, nil) }},
+		{"Subset", 2, func(is *assert.Is) { is.Subset([]int{}, []int{1}) }},
+		{"Superset", 2, func(is *assert.Is) { is.Superset([]int{}, []int{1}) }},
+		{"IsType", 2, func(is *assert.Is) { is.IsType(1, "1") }},
+		{"LinesElementsMatch", 2, func(is *assert.Is) { is.LinesElementsMatch(strings.NewReader("a"), nil) }},
+		{"EqualGroupedUnordered", 2, func(is *assert.Is) { is.EqualGroupedUnordered(nil, [][]int{{1}}) }},
+		{"EqualLazy", 2, func(is *assert.Is) { is.EqualLazy(1, 2) }},
+		{"NormalizedEqual", 2, func(is *assert.Is) { is.NormalizedEqual(1, 2) }},
+		{"SignatureEqual", 2, func(is *assert.Is) { is.SignatureEqual(func() {}, func(int) {}) }},
+		{"JSONEqual", 2, func(is *assert.Is) { is.JSONEqual("1", "2") }},
+		{"JSONEqualBytes", 2, func(is *assert.Is) { is.JSONEqualBytes([]byte("1"), []byte("2")) }},
+		{"InDelta", 2, func(is *assert.Is) { is.InDelta(1, 2, 0) }},
+		{"InEpsilon", 2, func(is *assert.Is) { is.InEpsilon(1, 2, 0) }},
+		{"Match", 2, func(is *assert.Is) { is.Match("a", "b") }},
+		{"NotMatch", 2, func(is *assert.Is) { is.NotMatch("a", "a") }},
+		{"HasPrefix", 2, func(is *assert.Is) { is.HasPrefix("a", "b") }},
+		{"HasSuffix", 2, func(is *assert.Is) { is.HasSuffix("a", "b") }},
 		{"NoError", 2, func(is *assert.Is) { is.NoError(errWrong) }},
 		{"Error", 2, func(is *assert.Is) { is.Error(nil) }},
 		{"ErrorAs", 2, func(is *assert.Is) {
 			var e *QueryError
 			is.ErrorAs(errors.New("it's not query error"), &e)
 		}},
+		{"ErrorContains", 2, func(is *assert.Is) { is.ErrorContains(nil, "") }},
+		{"EqualError", 2, func(is *assert.Is) { is.EqualError(nil, "") }},
+		{"NotErrorIs", 2, func(is *assert.Is) { is.NotErrorIs(errWrong, errWrong) }},
+		{"Failf", 2, func(is *assert.Is) { is.Failf("") }},
+		{"FailNowf", 2, func(is *assert.Is) { is.FailNowf("") }},
 		{"True", 2, func(is *assert.Is) { is.True(1 == 2) }},
+		{"Truef", 2, func(is *assert.Is) { is.Truef(1 == 2, "") }},
+		{"False", 2, func(is *assert.Is) { is.False(1 == 1) }},
+		{"Eventually", 2, func(is *assert.Is) { is.Eventually(func() bool { return false }, 0, time.Millisecond) }},
+		{"Never", 2, func(is *assert.Is) { is.Never(func() bool { return true }, 0, time.Millisecond) }},
+		{"Receives", 2, func(is *assert.Is) { is.Receives(make(chan int), 0, 0) }},
+		{"Closed", 2, func(is *assert.Is) { is.Closed(make(chan int), 0) }},
+		{"NoGoroutineLeak", 2, func(is *assert.Is) {
+			release := make(chan struct{})
+			defer close(release)
+			is.NoGoroutineLeak(func() { go func() { <-release }() })
+		}},
 		{"Panic", 3, func(is *assert.Is) { is.Panic(func() {}) }},
+		{"PanicMatches", 3, func(is *assert.Is) { is.PanicMatches(func() {}, "") }},
+		{"PanicAs", 3, func(is *assert.Is) {
+			var qe *QueryError
+			is.PanicAs(func() {}, &qe)
+		}},
 	}
 
 	for _, tt := range tests {
@@ -315,11 +2823,65 @@ func TestHelperPanic(t *testing.T) {
 		f    func()
 	}{
 		{"is.Equal panic", func() { is.Equal(1, 1) }},
+		{"is.NotEqual panic", func() { is.NotEqual(1, 2) }},
+		{"is.EqualValues panic", func() { is.EqualValues(1, 2) }},
+		{"is.Same panic", func() { is.Same(nil, nil) }},
+		{"is.NotSame panic", func() { is.NotSame(nil, nil) }},
+		{"is.Nil panic", func() { is.Nil(nil) }},
+		{"is.NotNil panic", func() { is.NotNil(nil) }},
+		{"is.Len panic", func() { is.Len(nil, 0) }},
+		{"is.Empty panic", func() { is.Empty(nil) }},
+		{"is.NotEmpty panic", func() { is.NotEmpty(nil) }},
+		{"is.Zero panic", func() { is.Zero(nil) }},
+		{"is.NotZero panic", func() { is.NotZero(nil) }},
+		{"is.MapHasKey panic", func() { is.MapHasKey(nil, nil) }},
+		{"is.MapValue panic", func() { is.MapValue(nil, nil, nil) }},
+		{"is.TypeOneOf panic", func() { is.TypeOneOf(nil) }},
+		{"is.GraphEqual panic", func() { is.GraphEqual(nil, nil) }},
+		{"is.MapOrderEqual panic", func() { is.MapOrderEqual(nil, nil, nil) }},
+		{"is.WithinDuration panic", func() { is.WithinDuration(time.Time{}, time.Time{}, 0) }},
+		{"is.TimeFormatEqual panic", func() { is.TimeFormatEqual("", "", time.Time{}) }},
+		{"is.NullableTimeEqual panic", func() { is.NullableTimeEqual(nil, nil, 0) }},
+		{"is.Implements panic", func() { is.Implements(nil, nil) }},
+		{"is.EqualStripANSI panic", func() { is.EqualStripANSI("", "") }},
+		{"is.ElementsMatch panic", func() { is.ElementsMatch(nil, nil) }},
+		{"is.Subset panic", func() { is.Subset(nil, nil) }},
+		{"is.Superset panic", func() { is.Superset(nil, nil) }},
+		{"is.IsType panic", func() { is.IsType(nil, nil) }},
+		{"is.LinesElementsMatch panic", func() { is.LinesElementsMatch(nil, nil) }},
+		{"is.EqualGroupedUnordered panic", func() { is.EqualGroupedUnordered(nil, nil) }},
+		{"is.EqualLazy panic", func() { is.EqualLazy(nil, nil) }},
+		{"is.NormalizedEqual panic", func() { is.NormalizedEqual(nil, nil) }},
+		{"is.SignatureEqual panic", func() { is.SignatureEqual(nil, nil) }},
+		{"is.JSONEqual panic", func() { is.JSONEqual("", "") }},
+		{"is.JSONEqualBytes panic", func() { is.JSONEqualBytes(nil, nil) }},
+		{"is.InDelta panic", func() { is.InDelta(0, 0, 0) }},
+		{"is.InEpsilon panic", func() { is.InEpsilon(0, 0, 0) }},
+		{"is.Match panic", func() { is.Match("", "") }},
+		{"is.NotMatch panic", func() { is.NotMatch("", "") }},
 		{"is.NoError panic", func() { is.NoError(nil) }},
+		{"is.HasPrefix panic", func() { is.HasPrefix("", "") }},
+		{"is.HasSuffix panic", func() { is.HasSuffix("", "") }},
 		{"is.Error panic", func() { is.Error(nil) }},
 		{"is.ErrorAs panic", func() { is.ErrorAs(nil, nil) }},
+		{"is.ErrorContains panic", func() { is.ErrorContains(nil, "") }},
+		{"is.EqualError panic", func() { is.EqualError(nil, "") }},
+		{"is.NotErrorIs panic", func() { is.NotErrorIs(nil, errWrong) }},
+		{"is.Failf panic", func() { is.Failf("") }},
+		{"is.FailNowf panic", func() { is.FailNowf("") }},
+		{"is.Truef panic", func() { is.Truef(true, "") }},
 		{"is.True panic", func() { is.True(false) }},
+		{"is.False panic", func() { is.False(true) }},
+		{"is.Eventually panic", func() { is.Eventually(nil, 0, 0) }},
+		{"is.Never panic", func() { is.Never(nil, 0, 0) }},
+		{"is.Receives panic", func() { is.Receives(nil, nil, 0) }},
+		{"is.Closed panic", func() { is.Closed(nil, 0) }},
+		{"is.NoGoroutineLeak panic", func() { is.NoGoroutineLeak(nil) }},
 		{"is.Panic panic", func() { is.Panic(nil) }},
+		{"is.PanicAs panic", func() { is.PanicAs(nil, nil) }},
+		{"is.PanicMatches panic", func() { is.PanicMatches(nil, "") }},
+		{"is.DebugCaller panic", func() { is.DebugCaller() }},
+		{"is.Summary panic", func() { is.Summary() }},
 	}
 
 	for _, tt := range tests {
@@ -360,3 +2922,163 @@ func TestExportedField(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadSubdirectory(t *testing.T) {
+	file, err := filepath.Abs(filepath.Join("testdata", "helper", "helper_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := 11 // is.True(n > 0) // positive
+	if got, want := assert.LoadedArgument(file, line), "n > 0"; got != want {
+		t.Errorf("%q != %q", got, want)
+	}
+	if got, want := assert.LoadedComment(file, line), "// positive"; got != want {
+		t.Errorf("%q != %q", got, want)
+	}
+}
+
+func TestNewTB(t *testing.T) {
+	var tb testing.TB = new(mockTB)
+	is := assert.New(tb)
+	is.Equal(1, 2) // benchmark
+	m := tb.(*mockTB)
+	assertState(t, m.state, fail)
+	if want := "is.Equal: 1 != 2 // benchmark"; m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+}
+
+func FuzzAssertions(f *testing.F) {
+	is := assert.New(f)
+	seeds := []int{0, 1, -1, math.MaxInt32}
+	is.NotEmpty(seeds) // seed corpus
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, n int) {
+		is := assert.New(t)
+		is.Equal(n+1-1, n) // identity
+
+		m := new(mockT)
+		fuzzed := assert.New(m)
+		fuzzed.True(n != n) // never
+		if want := "is.True: n != n // never"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+		fuzzed.NoError(fmt.Errorf("fuzzed %d", n))
+		assertState(t, m.state, failNow)
+	})
+}
+
+func TestLoadNonTestFile(t *testing.T) {
+	abs, err := filepath.Abs("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the callers synthesized by the fuzzing engine or the runtime have no Go source
+	for _, file := range []string{"", "<autogenerated>", abs, filepath.Join("testdata", "missing_test.go")} {
+		if got := assert.LoadedArgument(file, 1); got != "" {
+			t.Errorf("%q != %q", got, "")
+		}
+		if got := assert.LoadedComment(file, 1); got != "" {
+			t.Errorf("%q != %q", got, "")
+		}
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	src, err := ioutil.ReadFile(filepath.Join("testdata", "malformed", "malformed_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// parse this file as if it is malformed
+	_, file, line, _ := runtime.Caller(0)
+	assert.LoadSourceFrom(file, src)
+	defer assert.ResetSources()
+
+	if got := assert.LoadedComment(file, line); got != "" {
+		t.Errorf("%q != %q", got, "")
+	}
+
+	m := new(mockT)
+	is := assert.New(m)
+	is.Equal(1, 2) // the comment is not loaded
+	assertState(t, m.state, fail)
+	if want := "is.Equal: 1 != 2"; m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+
+	is.True(1 == 2)
+	if want := "is.True: <expression unavailable>"; m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+}
+
+func TestSourceUnavailable(t *testing.T) {
+	// parse this file as if it is built elsewhere and has no source
+	_, file, _, _ := runtime.Caller(0)
+	assert.LoadSourceFrom(file, []byte("package is_test\n"))
+	defer assert.ResetSources()
+
+	tests := []struct {
+		name string
+		msg  string
+		f    func(is *assert.Is)
+	}{
+		{"True", `is.True: <expression unavailable>`,
+			func(is *assert.Is) { is.True(1 == 2) }},
+		{"True with operands", `is.True: <expression unavailable> (1, 2)`,
+			func(is *assert.Is) { is.True(1 == 2, 1, 2) }},
+		{"False", `is.False: <expression unavailable>`,
+			func(is *assert.Is) { is.False(1 == 1) }},
+		{"Truef", `is.Truef: she is 17`,
+			func(is *assert.Is) { is.Truef(false, "she is %d", 17) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(mockT)
+			is := assert.New(m)
+			tt.f(is)
+
+			assertState(t, m.state, fail)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestLoadComment(t *testing.T) {
+	file, err := filepath.Abs(filepath.Join("testdata", "comments", "comments_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		line int
+		want string
+	}{
+		{"leading and inline comment", 11, "// inline"},
+		{"comment within and after the call", 12, "// after"},
+		{"comment within multi-line call", 13, "// first line"},
+		{"line without call", 15, "// no call"},
+		{"comment after multi-line call", 16, "// last line"},
+		{"comment after multi-line call within a call", 19, "// nested call"},
+		{"comment after the nested multi-line call", 22, "// outer call"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assert.LoadedComment(file, tt.line); got != tt.want {
+				t.Errorf("%q != %q", got, tt.want)
+			}
+		})
+	}
+}
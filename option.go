@@ -0,0 +1,414 @@
+package is
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// Option configures the test helper created by New.
+type Option func(*Is)
+
+/*
+WithCompareTimeout limits how long is.Equal spends comparing two values,
+including the comparers of WithComparer and RegisterEqual.
+Comparing enormous or deeply nested structures may take long enough
+to make the test appear hung, with the timeout, is.Equal fails instead.
+
+		func TestHuge(t *testing.T) {
+			is := is.New(t, is.WithCompareTimeout(5*time.Second))
+			is.Equal(hugeTree(), hugeTree())
+		}
+
+Will output (if the comparison takes longer than 5s):
+
+		is.Equal: comparison exceeded 5s (values too large?)
+
+The comparison runs in its own goroutine and it can't be stopped,
+so upon timeout the goroutine keeps running in the background until
+the comparison completes.
+*/
+func WithCompareTimeout(d time.Duration) Option {
+	return func(is *Is) {
+		is.compareTimeout = d
+	}
+}
+
+// AssertionEvent is the result of an assertion sent to the observer.
+type AssertionEvent struct {
+	Method  string // name of the assertion method, e.g. Equal
+	File    string // file of the assertion line
+	Line    int    // line number of the assertion line
+	Passed  bool
+	Message string // the failure message as logged, empty if passed
+}
+
+/*
+WithObserver sends the result of every assertion, passed or failed,
+to observer. It enables custom reporters and test analytics.
+
+		func TestObserver(t *testing.T) {
+			is := is.New(t, is.WithObserver(func(e is.AssertionEvent) {
+				fmt.Printf("%s:%d %s passed=%t\n", e.File, e.Line, e.Method, e.Passed)
+			}))
+			is.Equal(1, 1)
+		}
+*/
+func WithObserver(observer func(AssertionEvent)) Option {
+	return func(is *Is) {
+		is.observer = observer
+	}
+}
+
+/*
+WithDiff reports the diff by github.com/google/go-cmp/cmp instead of
+printing both values when is.Equal fails on structs, maps, slices and arrays.
+
+		func TestWithDiff(t *testing.T) {
+			is := is.New(t, is.WithDiff())
+			got := User{Name: "Alice", Age: 17}
+			is.Equal(got, User{Name: "Alice", Age: 18}) // she's an adult
+		}
+
+Will output:
+
+		is.Equal: values differ // she's an adult
+			  main.User{
+			  	Name: "Alice",
+			- 	Age:  17,
+			+ 	Age:  18,
+			  }
+*/
+func WithDiff() Option {
+	return func(is *Is) {
+		is.diff = true
+	}
+}
+
+/*
+WithCmpOptions makes is.Equal compare the values by cmp.Equal with opts
+when they aren't deeply equal, the diff of WithDiff honors opts as well.
+
+		func TestWithCmpOptions(t *testing.T) {
+			is := is.New(t, is.WithCmpOptions(cmpopts.EquateApprox(0, 1e-9)))
+			total := sum(0.1, 0.2)
+			is.Equal(total, 0.3) // passed
+		}
+*/
+func WithCmpOptions(opts ...cmp.Option) Option {
+	return func(is *Is) {
+		is.cmpOptions = append(is.cmpOptions[:len(is.cmpOptions):len(is.cmpOptions)], opts...)
+	}
+}
+
+/*
+Relaxed makes the assertions that use t.FailNow, such as is.Error, is.NoError and is.ErrorAs,
+use t.Fail instead so every assertion runs even when some fail.
+The failures are collected to be reported by is.Summary.
+The test helpers made by is.New share the same collection.
+
+		func TestRelaxed(t *testing.T) {
+			is := is.New(t, is.Relaxed())
+			defer is.Summary()
+			is.NoError(errors.New("first"))  // still continue
+			is.NoError(errors.New("second")) // still continue
+		}
+*/
+func Relaxed() Option {
+	return func(is *Is) {
+		is.relaxed = true
+		is.failures = new(failures)
+	}
+}
+
+/*
+WithThousandsSeparator makes is.Equal render the integers with a comma every three digits.
+
+		func TestWithThousandsSeparator(t *testing.T) {
+			is := is.New(t, is.WithThousandsSeparator())
+			is.Equal(population(), 1000001)
+		}
+
+Will output:
+
+		is.Equal: 1,000,000 != 1,000,001
+*/
+func WithThousandsSeparator() Option {
+	return func(is *Is) {
+		is.thousands = true
+	}
+}
+
+/*
+WithPercentDiff makes is.Equal report the relative difference of the numbers,
+computed as (a-b)/b, it helps to judge whether the mismatch is significant.
+
+		func TestWithPercentDiff(t *testing.T) {
+			is := is.New(t, is.WithPercentDiff())
+			is.Equal(visitors(), 100)
+		}
+
+Will output:
+
+		is.Equal: 105 != 100 (5% higher)
+*/
+func WithPercentDiff() Option {
+	return func(is *Is) {
+		is.percent = true
+	}
+}
+
+/*
+WithStableOutput makes the failure messages byte-identical across runs and platforms,
+so they can be diffed by CI or flaky test detection.
+The map keys are printed sorted and the struct fields in their declaration order as fmt does,
+the memory addresses are masked as 0x? and the operands are cut to 1000 bytes.
+
+		func TestWithStableOutput(t *testing.T) {
+			is := is.New(t, is.WithStableOutput())
+			is.Equal(&girl{"Alice"}, &girl{"Bob"})
+		}
+
+Will output:
+
+		is.Equal: &{Alice} != &{Bob}
+*/
+func WithStableOutput() Option {
+	return func(is *Is) {
+		is.stable = true
+	}
+}
+
+/*
+WithLooseJSON makes is.JSONEqual treat the strings encoding a number or a boolean
+as that number or boolean, e.g. "3" equals 3 and "true" equals true,
+for the APIs that are inconsistent about them.
+
+		func TestWithLooseJSON(t *testing.T) {
+			is := is.New(t, is.WithLooseJSON())
+			is.JSONEqual(`{"age": "17"}`, `{"age": 18}`)
+		}
+
+Will output:
+
+		is.JSONEqual: {"age":17} != {"age":18} (coerced)
+			at $.age: 17 != 18
+*/
+func WithLooseJSON() Option {
+	return func(is *Is) {
+		is.looseJSON = true
+	}
+}
+
+/*
+WithTestifyOrder makes is.Equal label its first operand as expected and the second as actual
+upon failing the test, the convention of testify, to ease the migration from it.
+It's purely cosmetic, is.Equal compares the operands the same regardless of their order.
+
+		func TestWithTestifyOrder(t *testing.T) {
+			is := is.New(t, is.WithTestifyOrder())
+			is.Equal(17, age()) // expected, actual
+		}
+
+Will output:
+
+		is.Equal: expected: 17, actual: 18 // expected, actual
+*/
+func WithTestifyOrder() Option {
+	return func(is *Is) {
+		is.testifyOrder = true
+	}
+}
+
+/*
+NilMapSliceEqual makes is.Equal treat a nil slice or map as equal to an empty one of the same type,
+also inside the structs, slices and maps, for the values that round-trip through JSON.
+By default is.Equal tells them apart as reflect.DeepEqual does.
+
+		func TestNilMapSliceEqual(t *testing.T) {
+			is := is.New(t, is.NilMapSliceEqual())
+			var exes []string
+			is.Equal(exes, []string{}) // passed, no one
+		}
+*/
+func NilMapSliceEqual() Option {
+	return WithCmpOptions(cmpopts.EquateEmpty())
+}
+
+/*
+IgnoreUnexported makes is.Equal compare only the exported fields of the structs of the same types as typs,
+so their internal state such as a sync.Mutex or a cached value doesn't matter.
+Upon failing the test, is.Equal reports the diff of their exported fields as WithDiff does.
+
+		type Girl struct {
+			Name  string
+			dates int
+		}
+
+		func TestIgnoreUnexported(t *testing.T) {
+			is := is.New(t, is.IgnoreUnexported(Girl{}))
+			is.Equal(Girl{"Alice", 3}, Girl{"Alice", 0}) // passed, she forgot
+		}
+*/
+func IgnoreUnexported(typs ...interface{}) Option {
+	return func(is *Is) {
+		WithCmpOptions(cmpopts.IgnoreUnexported(typs...))(is)
+		is.ignoreUnexported = true
+	}
+}
+
+/*
+WithComparer makes is.Equal compare the values of type T by eq before anything else,
+it wins over the comparator registered by RegisterEqual for the same type.
+The test helpers created by is.New inherit the comparers.
+
+		func TestWithComparer(t *testing.T) {
+			is := is.New(t, is.WithComparer(func(a, b time.Time) bool { return a.Equal(b) }))
+			is.Equal(firstDate().UTC(), firstDate()) // passed, the same moment
+		}
+*/
+func WithComparer[T any](eq func(a, b T) bool) Option {
+	return func(is *Is) {
+		comparers := make(map[reflect.Type]func(a, b interface{}) bool, len(is.comparers)+1)
+		for typ, eq := range is.comparers {
+			comparers[typ] = eq
+		}
+		comparers[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b interface{}) bool {
+			return eq(a.(T), b.(T))
+		}
+		is.comparers = comparers
+	}
+}
+
+/*
+WithOutput makes the test helper write the failure messages to w instead of t.Log,
+for the custom test harnesses and the minimal T that doesn't keep the logs.
+The test still fails by the t.Fail or t.FailNow of T as usual.
+w must be safe for concurrent use if the test helper is used by multiple goroutines.
+
+		func TestWithOutput(t *testing.T) {
+			var buf bytes.Buffer
+			is := is.New(t, is.WithOutput(&buf))
+			is.Equal(age(), 17)
+			report(buf.String()) // is.Equal: 18 != 17
+		}
+*/
+func WithOutput(w io.Writer) Option {
+	return func(is *Is) {
+		is.output = w
+	}
+}
+
+/*
+WithPrefix replaces the is of the assertion names that prefix the failure messages by namespace,
+for the projects that wrap the package under their own name. The default is is.
+
+		func TestWithPrefix(t *testing.T) {
+			is := is.New(t, is.WithPrefix("assert"))
+			is.Equal(age(), 17) // too young
+		}
+
+Will output:
+
+		assert.Equal: 18 != 17 // too young
+*/
+func WithPrefix(namespace string) Option {
+	return func(is *Is) {
+		is.namespace = namespace
+	}
+}
+
+/*
+JSONReport makes the test helper write every failure to w as a JSON object per line
+for the machine consumption, e.g. a CI dashboard, the failures are still logged as usual.
+The message and comment are reported without the assertion name and the // of the comment.
+w must be safe for concurrent use if the test helper is used by multiple goroutines.
+
+		func TestJSONReport(t *testing.T) {
+			is := is.New(t, is.JSONReport(os.Stderr))
+			is.Equal(age(), 17) // too young
+		}
+
+Will output:
+
+		{"assert":"Equal","file":"girl_test.go","line":12,"message":"18 != 17","comment":"too young"}
+*/
+func JSONReport(w io.Writer) Option {
+	return func(is *Is) {
+		is.jsonReport = w
+	}
+}
+
+/*
+TAPOutput makes the test helper write the result of every assertion, passed or failed,
+to w in the Test Anything Protocol, numbered in the order the assertions run.
+The lines of a multi-line message after the first one are written as the diagnostics.
+The test helpers created by is.New continue the numbering of is,
+the plan isn't written since the number of the assertions isn't known upfront.
+
+		func TestTAPOutput(t *testing.T) {
+			is := is.New(t, is.TAPOutput(os.Stdout))
+			is.Equal(herName(), "Alice")
+			is.True(herAge() >= 18) // legal
+		}
+
+Will output:
+
+		ok 1 - is.Equal
+		not ok 2 - is.True: herAge() >= 18 // legal
+*/
+func TAPOutput(w io.Writer) Option {
+	return func(is *Is) {
+		is.tap = &tap{w: w}
+	}
+}
+
+/*
+WithLocation makes the test helper prepend the file and line of the assertion to the logged messages,
+for the custom T whose logs aren't decorated with them as testing.T does.
+
+		func TestWithLocation(t *testing.T) {
+			is := is.New(myT{t}, is.WithLocation())
+			is.Equal(age(), 17) // too young
+		}
+
+Will output:
+
+		girl_test.go:12: is.Equal: 18 != 17 // too young
+*/
+func WithLocation() Option {
+	return func(is *Is) {
+		is.location = true
+	}
+}
+
+/*
+WithExtraSkip makes the test helper skip n more stack frames to find the assertion line,
+so the comments and the locations are read from the caller of the functions wrapping the assertions,
+and is.True and is.False report the call to the wrapping function as their expression.
+n is the number of the wrapping functions between the caller and the assertion,
+i.e. 1 for a function calling the assertion directly, 2 if that function is called by another one.
+The wrapping functions should call t.Helper as well to have the test framework
+report the caller line.
+
+		func assertAdult(is *is.Is, age int) {
+			is.True(age >= 18)
+		}
+
+		func TestWithExtraSkip(t *testing.T) {
+			is := is.New(t, is.WithExtraSkip(1))
+			assertAdult(is, herAge()) // not yet
+		}
+
+Will output:
+
+		is.True: assertAdult(is, herAge()) // not yet
+*/
+func WithExtraSkip(n int) Option {
+	return func(is *Is) {
+		is.extraSkip = n
+	}
+}
@@ -0,0 +1,676 @@
+package is_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/billyzaelani/is"
+)
+
+func TestNewOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func(m *mockT) *assert.Is
+		msg  string
+	}{
+		{"without options",
+			func(m *mockT) *assert.Is { return assert.New(m) },
+			"is.Equal: {Alice 17 []} != {Alice 18 []}"},
+		{"with empty options",
+			func(m *mockT) *assert.Is { return assert.New(m, []assert.Option{}...) },
+			"is.Equal: {Alice 17 []} != {Alice 18 []}"},
+		{"with options",
+			func(m *mockT) *assert.Is { return assert.New(m, assert.WithDiff()) },
+			"is.Equal: values differ"},
+		{"inherit defaults",
+			func(m *mockT) *assert.Is { return assert.New(nil).New(m) },
+			"is.Equal: {Alice 17 []} != {Alice 18 []}"},
+		{"inherit options",
+			func(m *mockT) *assert.Is { return assert.New(nil, assert.WithDiff()).New(m) },
+			"is.Equal: values differ"},
+		{"options on top of inherited",
+			func(m *mockT) *assert.Is { return assert.New(nil).New(m, assert.WithDiff()) },
+			"is.Equal: values differ"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := tt.new(m)
+			is.Equal(user{"Alice", 17, nil}, user{"Alice", 18, nil})
+
+			assertState(t, m.state, fail)
+			if firstLine := strings.SplitN(m.msg, "\n", 2)[0]; firstLine != tt.msg {
+				t.Errorf("%q != %q", firstLine, tt.msg)
+			}
+		})
+	}
+}
+
+// slow is compared by a comparer that takes its time.
+type slow int
+
+func TestWithCompareTimeout(t *testing.T) {
+	comparer := assert.WithComparer(func(a, b slow) bool {
+		time.Sleep(100 * time.Millisecond)
+		return a == b
+	})
+	tests := []struct {
+		name  string
+		opts  []assert.Option
+		state failState
+		msg   string
+	}{
+		{"without timeout", []assert.Option{comparer}, pass, ``},
+		{"within timeout", []assert.Option{comparer, assert.WithCompareTimeout(time.Second)}, pass, ``},
+		{"exceeded timeout", []assert.Option{comparer, assert.WithCompareTimeout(10 * time.Millisecond)}, fail,
+			`is.Equal: comparison exceeded 10ms (values too large?)`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, tt.opts...)
+			is.Equal(slow(1), slow(1))
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("NotEqual", func(t *testing.T) {
+		t.Parallel()
+		m := new(mockT)
+		is := assert.New(m, comparer, assert.WithCompareTimeout(10*time.Millisecond))
+		is.NotEqual(slow(1), slow(2))
+
+		assertState(t, m.state, fail)
+		if want := `is.NotEqual: comparison exceeded 10ms (values too large?)`; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
+
+func TestWithObserver(t *testing.T) {
+	var events []assert.AssertionEvent
+	m := new(mockT)
+	is := assert.New(m, assert.WithObserver(func(e assert.AssertionEvent) {
+		events = append(events, e)
+	}))
+
+	_, _, line, _ := runtime.Caller(0)
+	is.Equal(1, 1)
+	is.Equal(1, 2) // one is not two
+	is.True(1 == 1)
+	is.NoError(errWrong)
+
+	want := []assert.AssertionEvent{
+		{Method: "Equal", Line: line + 1, Passed: true},
+		{Method: "Equal", Line: line + 2, Passed: false, Message: "is.Equal: 1 != 2 // one is not two"},
+		{Method: "True", Line: line + 3, Passed: true},
+		{Method: "NoError", Line: line + 4, Passed: false, Message: "is.NoError: something's wrong"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("%d events != %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if filepath.Base(e.File) != "option_test.go" {
+			t.Errorf("%q != %q", filepath.Base(e.File), "option_test.go")
+		}
+		e.File = ""
+		if e != want[i] {
+			t.Errorf("%+v != %+v", e, want[i])
+		}
+	}
+}
+
+func TestRelaxed(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		f     func(is *assert.Is)
+	}{
+		{"Error", fail, func(is *assert.Is) { is.Error(nil) }},
+		{"NoError", fail, func(is *assert.Is) { is.NoError(errWrong) }},
+		{"ErrorAs", fail, func(is *assert.Is) {
+			var e *QueryError
+			is.ErrorAs(errWrong, &e)
+		}},
+		{"ErrorContains", fail, func(is *assert.Is) { is.ErrorContains(errWrong, "network") }},
+		{"Equal", fail, func(is *assert.Is) { is.Equal(1, 2) }},
+		{"passed", pass, func(is *assert.Is) { is.NoError(nil) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			tt.f(assert.New(m, assert.Relaxed()))
+			assertState(t, m.state, tt.state)
+		})
+	}
+}
+
+func TestWithTestifyOrder(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"equal", pass, ``,
+			func(is *assert.Is) { is.Equal(17, 17) }},
+		{"not equal", fail, prefix + `expected: 17, actual: 18 // age`,
+			func(is *assert.Is) { is.Equal(17, 18) /* age */ }},
+		{"different data type", fail, prefix + `expected: int(17), actual: string(17)`,
+			func(is *assert.Is) { is.Equal(17, "17") }},
+		{"nil", fail, prefix + `expected: <nil>, actual: int(17)`,
+			func(is *assert.Is) { is.Equal(nil, 17) }},
+		{"generic", fail, prefix + `expected: int(17), actual: int(18)`,
+			func(is *assert.Is) { assert.Equal(is, 17, 18) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.WithTestifyOrder())
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestNilMapSliceEqual(t *testing.T) {
+	prefix := "is.Equal: "
+	type inbox struct {
+		Messages []string
+	}
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"nil and empty slice", pass, ``,
+			func(is *assert.Is) { is.Equal([]string(nil), []string{}) }},
+		{"empty and nil map", pass, ``,
+			func(is *assert.Is) { is.Equal(map[string]int{}, map[string]int(nil)) }},
+		{"nested nil slice", pass, ``,
+			func(is *assert.Is) { is.Equal(inbox{}, inbox{Messages: []string{}}) }},
+		{"nil and non-empty slice", fail, prefix + "len 0 != len 2 // still strict\n\t[] != [one two]",
+			func(is *assert.Is) { is.Equal([]string(nil), []string{"one", "two"}) /* still strict */ }},
+		{"different element type", fail, prefix + `[]string([]) != []int([])`,
+			func(is *assert.Is) { is.Equal([]string(nil), []int{}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.NilMapSliceEqual())
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("strict by default", func(t *testing.T) {
+		m := new(mockT)
+		assert.New(m).Equal([]string(nil), []string{})
+		assertState(t, m.state, fail)
+
+		m = new(mockT)
+		assert.New(m).Equal(map[string]int(nil), map[string]int{})
+		assertState(t, m.state, fail)
+	})
+}
+
+// visitor carries an unexported counter that IgnoreUnexported ignores.
+type visitor struct {
+	Name   string
+	visits int
+}
+
+// tally is not ignored so its unexported counter still matters.
+type tally struct {
+	Name  string
+	count int
+}
+
+func TestIgnoreUnexported(t *testing.T) {
+	prefix := "is.Equal: "
+	tests := []struct {
+		name  string
+		state failState
+		lines []string // lines the message must contain
+		f     func(is *assert.Is)
+	}{
+		{"unexported differs", pass, nil,
+			func(is *assert.Is) { is.Equal(visitor{"Alice", 1}, visitor{"Alice", 2}) }},
+		{"pointer", pass, nil,
+			func(is *assert.Is) { is.Equal(&visitor{"Alice", 1}, &visitor{"Alice", 2}) }},
+		{"nested", pass, nil,
+			func(is *assert.Is) { is.Equal([]visitor{{"Alice", 1}}, []visitor{{"Alice", 2}}) }},
+		{"exported differs", fail, []string{
+			prefix + "values differ // new visitor",
+			`Name: "Alice",`,
+			`Name: "Bob",`,
+			`... // 1 ignored field`,
+		},
+			func(is *assert.Is) { is.Equal(visitor{"Alice", 1}, visitor{"Bob", 1}) /* new visitor */ }},
+		{"not registered", fail, []string{prefix + "values differ", "count: 1,", "count: 2,"},
+			func(is *assert.Is) { is.Equal(tally{"Alice", 1}, tally{"Alice", 2}) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.IgnoreUnexported(visitor{}))
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			for _, line := range tt.lines {
+				if !strings.Contains(m.msg, line) {
+					t.Errorf("%q does not contain %q", m.msg, line)
+				}
+			}
+		})
+	}
+}
+
+// meters is a float wrapper compared with a tolerance by WithComparer.
+type meters float64
+
+func TestWithComparer(t *testing.T) {
+	prefix := "is.Equal: "
+	now := time.Now()
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"monotonic clock", pass, ``,
+			func(is *assert.Is) { is.Equal(now, now.Round(0)) }},
+		{"location", pass, ``,
+			func(is *assert.Is) { is.Equal(now.UTC(), now) }},
+		{"tolerance", pass, ``,
+			func(is *assert.Is) { is.Equal(meters(1), meters(1.0004)) }},
+		{"out of tolerance", fail, prefix + `1 != 1.01 // too far`,
+			func(is *assert.Is) { is.Equal(meters(1), meters(1.01)) /* too far */ }},
+		{"wins over RegisterEqual", fail, prefix + `{100 USD} != {100 usd}`,
+			func(is *assert.Is) { is.Equal(Money{100, "USD"}, Money{100, "usd"}) }},
+		{"other types", fail, prefix + `1 != 2`,
+			func(is *assert.Is) { is.Equal(1, 2) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m,
+				assert.WithComparer(func(a, b time.Time) bool { return a.Equal(b) }),
+				assert.WithComparer(func(a, b meters) bool { return math.Abs(float64(a-b)) < 1e-3 }),
+				assert.WithComparer(func(a, b Money) bool { return a == b }),
+			)
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+
+	t.Run("inherited by New", func(t *testing.T) {
+		is := assert.New(new(mockT), assert.WithComparer(func(a, b meters) bool { return true }))
+		m := new(mockT)
+		is.New(m).Equal(meters(1), meters(2))
+		assertState(t, m.state, pass)
+	})
+
+	t.Run("not shared with other helpers", func(t *testing.T) {
+		m := new(mockT)
+		assert.New(m).Equal(meters(1), meters(1.0004))
+		assertState(t, m.state, fail)
+	})
+}
+
+func TestWithOutput(t *testing.T) {
+	var buf bytes.Buffer
+	m := new(mockT)
+	is := assert.New(m, assert.WithOutput(&buf), assert.Relaxed())
+	is.Equal(1, 1)
+	is.Equal(1, 2) // one is not two
+	is.NoError(errWrong)
+
+	assertState(t, m.state, fail)
+	if m.msg != "" {
+		t.Errorf("%q != %q", m.msg, "")
+	}
+	want := "is.Equal: 1 != 2 // one is not two\nis.NoError: something's wrong\n"
+	if got := buf.String(); got != want {
+		t.Errorf("%q != %q", got, want)
+	}
+	if msg := is.LastMessage(); msg != "is.NoError: something's wrong" {
+		t.Errorf("%q != %q", msg, "is.NoError: something's wrong")
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		state failState
+		msg   string
+		f     func(is *assert.Is)
+	}{
+		{"Equal", fail, `assert.Equal: 1 != 2 // one is not two`,
+			func(is *assert.Is) { is.Equal(1, 2) /* one is not two */ }},
+		{"True", fail, `assert.True: 1 == 2`,
+			func(is *assert.Is) { is.True(1 == 2) }},
+		{"Error", failNow, `assert.Error: <nil>`,
+			func(is *assert.Is) { is.Error(nil) }},
+		{"NoError", failNow, `assert.NoError: something's wrong`,
+			func(is *assert.Is) { is.NoError(errWrong) }},
+		{"ErrorAs", failNow, `assert.ErrorAs: err != **is_test.QueryError`,
+			func(is *assert.Is) {
+				var e *QueryError
+				is.ErrorAs(errWrong, &e)
+			}},
+		{"Panic", fail, `assert.Panic: the function is not panic`,
+			func(is *assert.Is) { is.Panic(func() {}) }},
+		{"generic", fail, `assert.Equal: int(1) != int(2)`,
+			func(is *assert.Is) { assert.Equal(is, 1, 2) }},
+		{"Summary", fail, "assert.Summary: 1 failures\nassert.Equal: 1 != 2",
+			func(is *assert.Is) {
+				relaxed := is.New(is.T, assert.Relaxed())
+				relaxed.Equal(1, 2)
+				relaxed.Summary()
+			}},
+		{"inherited by New", failNow, `assert.NoError: something's wrong`,
+			func(is *assert.Is) { is.New(is.T).NoError(errWrong) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := new(mockT)
+			is := assert.New(m, assert.WithPrefix("assert"))
+			tt.f(is)
+
+			assertState(t, m.state, tt.state)
+			if m.msg != tt.msg {
+				t.Errorf("%q != %q", m.msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	var buf bytes.Buffer
+	m := new(mockT)
+	is := assert.New(m, assert.JSONReport(&buf), assert.Relaxed())
+	is.Equal(1, 1)
+	_, _, line, _ := runtime.Caller(0)
+	is.Equal(1, 2) // one is not two
+	is.Error(nil)
+
+	assertState(t, m.state, fail)
+	if want := "is.Error: <nil>"; m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+
+	type record struct {
+		Assert  string
+		File    string
+		Line    int
+		Message string
+		Comment string
+	}
+	want := []record{
+		{"Equal", "option_test.go", line + 1, "1 != 2", "one is not two"},
+		{"Error", "option_test.go", line + 2, "<nil>", ""},
+	}
+	dec := json.NewDecoder(&buf)
+	for _, w := range want {
+		var got record
+		if err := dec.Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("%+v != %+v", got, w)
+		}
+	}
+	if dec.More() {
+		t.Errorf("unexpected record after %d records", len(want))
+	}
+}
+
+func TestTAPOutput(t *testing.T) {
+	var buf bytes.Buffer
+	m := new(mockT)
+	is := assert.New(m, assert.TAPOutput(&buf), assert.Relaxed())
+	is.Equal(1, 1)
+	is.True(1 == 2) // one is not two
+	is.NoError(nil) // no error
+	is.New(m).Equal("a\nb\n", "a\nc\n")
+	assert.Equal(is, 1, 2)
+	is.Equal("# TODO", `C:\`)
+
+	assertState(t, m.state, fail)
+	want := `ok 1 - is.Equal
+not ok 2 - is.True: 1 == 2 // one is not two
+ok 3 - is.NoError // no error
+not ok 4 - is.Equal: strings differ
+# --- a
+# +++ b
+# @@ -1,2 +1,2 @@
+#  a
+# -b
+# +c
+not ok 5 - is.Equal: int(1) != int(2)
+not ok 6 - is.Equal: \# TODO != C:\\
+`
+	if got := buf.String(); got != want {
+		t.Errorf("%q != %q", got, want)
+	}
+}
+
+func TestWithLocation(t *testing.T) {
+	m := new(mockT)
+	is := assert.New(m, assert.WithLocation())
+	is.Equal(1, 1)
+	if m.msg != "" {
+		t.Errorf("%q != %q", m.msg, "")
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	is.Equal(1, 2) // one is not two
+	assertState(t, m.state, fail)
+	want := fmt.Sprintf("option_test.go:%d: is.Equal: 1 != 2 // one is not two", line+1)
+	if m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+	if msg := is.LastMessage(); msg != "is.Equal: 1 != 2 // one is not two" {
+		t.Errorf("%q != %q", msg, "is.Equal: 1 != 2 // one is not two")
+	}
+
+	assert.Equal(is, 1, 2)
+	want = fmt.Sprintf("option_test.go:%d: is.Equal: int(1) != int(2)", line+11)
+	if m.msg != want {
+		t.Errorf("%q != %q", m.msg, want)
+	}
+}
+
+// assertAdult wraps the assertions for TestWithExtraSkip.
+func assertAdult(is *assert.Is, age int) {
+	is.True(age >= 18)
+	is.Equal(age >= 18, true)
+}
+
+func TestWithExtraSkip(t *testing.T) {
+	m := new(mockT)
+	is := assert.New(m, assert.WithExtraSkip(1), assert.WithLocation(), assert.Relaxed())
+	var msgs []string
+	is = is.New(m, assert.WithObserver(func(e assert.AssertionEvent) {
+		msgs = append(msgs, fmt.Sprintf("%s:%d: %s", filepath.Base(e.File), e.Line, e.Message))
+	}))
+
+	_, _, line, _ := runtime.Caller(0)
+	assertAdult(is, 17) // not yet
+	assertState(t, m.state, fail)
+
+	loc := fmt.Sprintf("option_test.go:%d: ", line+1)
+	want := []string{
+		loc + "is.True: assertAdult(is, 17) // not yet",
+		loc + "is.Equal: false != true // not yet",
+	}
+	if len(msgs) != len(want) {
+		t.Fatalf("%q != %q", msgs, want)
+	}
+	for i := range want {
+		if msgs[i] != want[i] {
+			t.Errorf("%q != %q", msgs[i], want[i])
+		}
+	}
+	if m.msg != want[1] {
+		t.Errorf("%q != %q", m.msg, want[1])
+	}
+}
+
+func TestLastMessage(t *testing.T) {
+	m := new(mockT)
+	is := assert.New(m, assert.Relaxed())
+	if msg := is.LastMessage(); msg != "" {
+		t.Errorf("%q != %q", msg, "")
+	}
+
+	is.Equal(1, 2)
+	is.NoError(errWrong) // latest
+	is.Equal(1, 1)
+	if want := "is.NoError: something's wrong // latest"; is.LastMessage() != want {
+		t.Errorf("%q != %q", is.LastMessage(), want)
+	}
+
+	if msg := is.New(m).LastMessage(); msg != "" {
+		t.Errorf("%q != %q", msg, "")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m)
+		is.Equal(1, 2)
+		is.Summary()
+		if want := "is.Summary: 0 failures"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("relaxed", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.Relaxed())
+		is.NoError(errWrong)
+		is.Equal(1, 1)
+		is.Equal(1, 2) // one is not two
+		is.Summary()
+		want := "is.Summary: 2 failures\nis.NoError: something's wrong\nis.Equal: 1 != 2 // one is not two"
+		if m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+}
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestWithStableOutput(t *testing.T) {
+	run := func(opts ...assert.Option) string {
+		m := new(mockT)
+		is := assert.New(m, opts...)
+		is.Equal(node{"a", &node{"b", nil}}, node{"a", &node{"c", nil}})
+		return m.msg
+	}
+
+	t.Run("identical output", func(t *testing.T) {
+		first, second := run(assert.WithStableOutput()), run(assert.WithStableOutput())
+		if first != second {
+			t.Errorf("%q != %q", first, second)
+		}
+		if want := "is.Equal: {a 0x?} != {a 0x?}"; first != want {
+			t.Errorf("%q != %q", first, want)
+		}
+	})
+
+	t.Run("without option", func(t *testing.T) {
+		if msg := run(); strings.Contains(msg, "0x?") || !strings.Contains(msg, "0x") {
+			t.Errorf("%q doesn't contain the address", msg)
+		}
+	})
+
+	t.Run("hex in string", func(t *testing.T) {
+		type payload struct {
+			Magic string
+			Next  *node
+		}
+		m := new(mockT)
+		is := assert.New(m, assert.WithStableOutput())
+		is.Equal(payload{"0xdead", &node{}}, payload{"0xbeef", &node{}})
+		if want := "is.Equal: {0xdead 0x?} != {0xbeef 0x?}"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("without pointer", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.WithStableOutput())
+		is.Equal("0x1", "0x2")
+		if want := "is.Equal: 0x1 != 0x2"; m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		m := new(mockT)
+		is := assert.New(m, assert.WithStableOutput())
+		is.Equal([]string{strings.Repeat("a", 998) + "éé"}, []string{"b"})
+		want := "is.Equal: slices differ at index 0: " + strings.Repeat("a", 998) + "é... (2 more bytes) != b\n" +
+			"\t[" + strings.Repeat("a", 998) + "... (5 more bytes) != [b]"
+		if m.msg != want {
+			t.Errorf("%q != %q", m.msg, want)
+		}
+	})
+}
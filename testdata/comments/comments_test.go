@@ -0,0 +1,27 @@
+package comments
+
+import (
+	"testing"
+
+	"github.com/billyzaelani/is"
+)
+
+func TestComments(t *testing.T) {
+	is := is.New(t)
+	/* leading */ is.Equal(1, 2) // inline
+	is.Equal(1, /* within */ 2) // after
+	is.True(1 == 2 && /* first line */
+		false)
+	// no call
+	is.True(1 == 2 &&
+		false) // last line
+	t.Run("nested", func(t *testing.T) {
+		is.New(t).True(1 == 2 &&
+			false) // nested call
+	})
+	t.Run("after nested", func(t *testing.T) {
+		is.New(t).True(1 == 2 &&
+			false)
+		// outer call
+	})
+}
@@ -0,0 +1,12 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/billyzaelani/is"
+)
+
+func assertPositive(t *testing.T, n int) {
+	is := is.New(t)
+	is.True(n > 0) // positive
+}
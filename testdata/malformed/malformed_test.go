@@ -0,0 +1,8 @@
+package malformed
+
+import "testing"
+
+func TestMalformed(t *testing.T) {
+	is := is.New(t)
+	is.True(1 == 2 // the closing parenthesis is missing
+}
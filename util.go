@@ -1,96 +1,1087 @@
 package is
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/printer"
 	"go/token"
-	"os"
+	"io"
+	"math"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
-func loadTestFile() {
-	comments = make(map[string]map[int]string)
-	arguments = make(map[string]map[int]string)
-	root, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-
-	walkTest := func(path string, info os.FileInfo, err error) error {
-		if info.IsDir() && path != root {
-			return filepath.SkipDir
-		}
+// source is the comments and arguments parsed from a file.
+type source struct {
+	once      sync.Once
+	comments  map[int]string
+	arguments map[int][]argument // in the source order within each line
+	fset      *token.FileSet
+	calls     map[int]*ast.CallExpr // the first call on each line
+}
 
-		if strings.HasSuffix(info.Name(), "_test.go") {
-			comments[path] = loadComment(path)
-			arguments[path] = loadArgument(path, "True")
-		}
+// sources memoizes the parsed files keyed by their absolute path.
+var sources = struct {
+	sync.Mutex
+	files map[string]*source
+}{files: make(map[string]*source)}
 
-		return nil
+// loadSource returns the comments and arguments of file, parsing it upon the first call.
+func loadSource(file string) *source {
+	sources.Lock()
+	src, ok := sources.files[file]
+	if !ok {
+		src = new(source)
+		sources.files[file] = src
 	}
-	filepath.Walk(root, walkTest)
+	sources.Unlock()
+
+	src.once.Do(func() { src.parse(file, nil) })
+	return src
 }
 
-func loadComment(path string) map[int]string {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+// parse parses the comments and arguments of file, src is the same as parser.ParseFile.
+// An unparseable file has neither comments nor arguments.
+func (s *source) parse(file string, src interface{}) {
+	s.fset = token.NewFileSet()
+	f, err := parser.ParseFile(s.fset, file, src, parser.ParseComments|parser.AllErrors)
 	if err != nil {
-		panic(err)
+		return
+	}
+
+	s.calls = firstCalls(s.fset, f)
+	s.comments = loadComment(s.fset, f, s.calls)
+	s.arguments = loadArgument(s.fset, f, "True", "False", "Truef")
+}
+
+// call returns the source of the first call on line.
+func (s *source) call(line int) string {
+	call, ok := s.calls[line]
+	if !ok {
+		return ""
 	}
+
+	var str strings.Builder
+	printer.Fprint(&str, s.fset, call)
+	return strings.ReplaceAll(str.String(), "\n\t", " ")
+}
+
+// firstCalls returns the first call on each line of f, the assertion call of the line.
+func firstCalls(fset *token.FileSet, f *ast.File) map[int]*ast.CallExpr {
+	calls := make(map[int]*ast.CallExpr)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			line := fset.Position(call.Pos()).Line
+			if first, ok := calls[line]; !ok || call.Pos() < first.Pos() {
+				calls[line] = call
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// loadComment loads the comments of f given the first call on each line.
+func loadComment(fset *token.FileSet, f *ast.File, calls map[int]*ast.CallExpr) map[int]string {
 	comments := make(map[int]string)
+	// the comment of the assertion call is the first one after the call,
+	// or else the first one within the call, e.g. in the first line of a multi-line call.
+	// Other comments sharing the line are ignored, unless the line has no call.
+	// The comments on the following lines of a multi-line call belong to the call as well,
+	// if its first line has none.
+	after := make(map[int]bool)
+	spans := multiline(fset, calls)
 	for _, s := range f.Comments {
 		line := fset.Position(s.Pos()).Line
-		comments[line] = "// " + strings.TrimSpace(s.Text())
+		text := "// " + strings.TrimSpace(s.Text())
+		if start, ok := spanning(spans, line); ok {
+			if _, found := comments[start]; !found {
+				comments[start] = text
+			}
+		}
+		call, ok := calls[line]
+		switch {
+		case !ok:
+			comments[line] = text
+		case s.Pos() > call.End():
+			if !after[line] {
+				comments[line] = text
+				after[line] = true
+			}
+		case s.Pos() > call.Pos():
+			if _, found := comments[line]; !found {
+				comments[line] = text
+			}
+		}
 	}
 	return comments
 }
 
-func loadArgument(path, funcName string) map[int]string {
-	arguments := make(map[int]string)
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, path, nil, parser.AllErrors)
-	if err != nil {
-		panic(err)
+// span is the first and last line of a multi-line call,
+// parent is the index of the innermost span covering its first line, or -1.
+type span struct{ start, end, parent int }
+
+// multiline returns the spans of the multi-line calls sorted by their first line.
+func multiline(fset *token.FileSet, calls map[int]*ast.CallExpr) []span {
+	var spans []span
+	for line, call := range calls {
+		if end := fset.Position(call.End()).Line; end > line {
+			spans = append(spans, span{start: line, end: end})
+		}
 	}
-	ast.Inspect(f, func(n ast.Node) bool {
-		ret, ok := n.(*ast.CallExpr)
-		if ok {
-			var str strings.Builder
-			printer.Fprint(&str, fset, ret)
-			if expr := str.String(); strings.Contains(expr, funcName) {
-				line := fset.Position(ret.Pos()).Line
-				args := strings.ReplaceAll(expr, "\n\t", " ")
-				args = args[ret.Lparen-ret.Pos()+1 : len(args)-1]
-				arguments[line] = args
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	for i := range spans {
+		spans[i].parent = innermost(spans, i-1, spans[i].start)
+	}
+	return spans
+}
+
+// innermost returns the index of the innermost span covering line among spans[:i+1], or -1.
+// The spans skipped by the parent link end before the first line of its child, so before line too.
+func innermost(spans []span, i, line int) int {
+	for i >= 0 && spans[i].end < line {
+		i = spans[i].parent
+	}
+	return i
+}
+
+// spanning returns the first line of the innermost multi-line call of spans
+// that covers line other than its first line.
+func spanning(spans []span, line int) (int, bool) {
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].start >= line }) - 1
+	if i = innermost(spans, i, line); i < 0 {
+		return 0, false
+	}
+	return spans[i].start, true
+}
+
+// loadArgument loads the source of the arguments of every call to one of funcNames in f.
+func loadArgument(fset *token.FileSet, f *ast.File, funcNames ...string) map[int][]argument {
+	arguments := make(map[int][]argument)
+	print := func(n ast.Node) string {
+		var str strings.Builder
+		printer.Fprint(&str, fset, n)
+		return strings.ReplaceAll(str.String(), "\n\t", " ")
+	}
+	// function is the name of the function enclosing the calls within root, as the runtime
+	// names the closures, e.g. TestTrue.func1.func2. The closures of the package-level
+	// declarations are numbered across the package, so their name is unknown.
+	var inspect func(root ast.Node, function string)
+	inspect = func(root ast.Node, function string) {
+		closures := 0
+		ast.Inspect(root, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				if n == root {
+					return true
+				}
+				closures++
+				name := ""
+				if function != "" {
+					name = fmt.Sprintf("%s.func%d", function, closures)
+				}
+				inspect(n, name)
+				return false
+			case *ast.CallExpr:
+				if isCallTo(n, funcNames) && len(n.Args) > 0 {
+					line := fset.Position(n.Pos()).Line
+					arg := argument{expr: print(n.Args[0]), function: function}
+					if bin, ok := n.Args[0].(*ast.BinaryExpr); ok && isComparison(bin.Op) {
+						arg.operands = []string{print(bin.X), print(bin.Y)}
+					}
+					arguments[line] = append(arguments[line], arg)
+				}
 			}
+			return true
+		})
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			inspect(fn, funcDeclName(fn))
+		} else {
+			inspect(decl, "")
 		}
-		return true
-	})
+	}
 	return arguments
 }
 
+// funcDeclName returns the name of fn as the runtime names it without the package,
+// e.g. TestTrue, T.Method or (*T).Method, the type parameters are left out.
+func funcDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	typ, star := fn.Recv.List[0].Type, false
+	if s, ok := typ.(*ast.StarExpr); ok {
+		typ, star = s.X, true
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ = t.X
+	case *ast.IndexListExpr:
+		typ = t.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if star {
+		return "(*" + ident.Name + ")." + fn.Name.Name
+	}
+	return ident.Name + "." + fn.Name.Name
+}
+
+// argument is the source of the expression passed to is.True, is.False or is.Truef.
+type argument struct {
+	expr     string
+	operands []string // the left and right operands of a comparison expression
+	function string   // the name of the enclosing function, see loadArgument
+}
+
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
+
+// unavailable is the expression of the assertion whose source isn't available,
+// e.g. the test binary is built elsewhere.
+const unavailable = "<expression unavailable>"
+
+// describe returns the expression followed by the operands with their values, if any,
+// e.g. money != 0 (money == 0). The operands that print as their value are omitted.
+func (arg argument) describe(values []interface{}, format func(interface{}) string) string {
+	if arg.expr == "" {
+		arg.expr = unavailable
+	}
+
+	var operands []string
+	for i, v := range values {
+		val := format(v)
+		if i >= len(arg.operands) {
+			operands = append(operands, val)
+			continue
+		}
+		if arg.operands[i] != val {
+			operands = append(operands, arg.operands[i]+" == "+val)
+		}
+	}
+
+	if len(operands) == 0 {
+		return arg.expr
+	}
+	return fmt.Sprintf("%s (%s)", arg.expr, strings.Join(operands, ", "))
+}
+
+// call is the call site of an assertion.
+type call struct {
+	file     string
+	line     int
+	function string
+}
+
+// callSite returns the call site of is.True, is.False or is.Truef.
+func (is *Is) callSite() call {
+	pc := make([]uintptr, 1)
+	runtime.Callers(3+is.extraSkip, pc) // level of function call to the actual test
+	frame, _ := runtime.CallersFrames(pc).Next()
+	return call{file: frame.File, line: frame.Line, function: frame.Function}
+}
+
+var (
+	// typeArguments are the type arguments in the runtime name of a generic function.
+	typeArguments = regexp.MustCompile(`\[[^\]]*\]`)
+	// nestedClosure is the runtime name of a nested closure that isn't inlined, e.g. .1 for .func1.
+	nestedClosure = regexp.MustCompile(`\.(\d+)`)
+)
+
+// sourceName returns the runtime name of function the way loadArgument names it,
+// without the package and the type arguments, e.g. TestTrue.func1.func2.
+func sourceName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	name = typeArguments.ReplaceAllString(name, "")
+	return nestedClosure.ReplaceAllString(name, ".func$1")
+}
+
+// isCallTo reports whether call calls one of funcNames, either as a function or a method.
+func isCallTo(call *ast.CallExpr, funcNames []string) bool {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	default:
+		return false
+	}
+
+	for _, funcName := range funcNames {
+		if name == funcName {
+			return true
+		}
+	}
+	return false
+}
+
 // logf report the fail depends on failFunc, either t.Fail or t.FailNow.
 // skip is how deep the function call to reach the actual test.
-func (is *Is) logf(failFunc func(), skip int, format string, args ...interface{}) {
+// prefix is the name of the assertion, e.g. is.Equal.
+func (is *Is) logf(failFunc func(), skip int, prefix, format string, args ...interface{}) {
 	is.Helper()
+	skip += is.extraSkip
 
-	msg := []string{fmt.Sprintf(format, args...)}
-	if comment := is.loadComment(skip); comment != "" {
-		msg = append(msg, comment)
+	// the comment describes the first line of a multi-line message
+	body := fmt.Sprintf(format, args...)
+	comment := is.loadComment(skip)
+	msg := strings.SplitN(is.prefixed(prefix)+": "+body, "\n", 2)
+	if comment != "" {
+		msg[0] += " " + comment
+	}
+	message := strings.Join(msg, "\n")
+	is.mu.Lock()
+	is.last = message
+	is.mu.Unlock()
+	if is.location {
+		is.log(is.locate(skip) + message)
+	} else {
+		is.log(message)
+	}
+	is.failures.add(message)
+	is.observe(skip, prefix, false, message)
+	is.report(skip, prefix, body, comment)
+	if is.tap != nil {
+		is.tap.write(false, message)
 	}
-	is.Log(strings.Join(msg, " "))
 	failFunc()
 }
 
-func valWithType(v interface{}) string {
+// tapEscaper escapes the description of a TAP test point.
+var tapEscaper = strings.NewReplacer(`\`, `\\`, "#", `\#`)
+
+// tap is the TAP stream of TAPOutput, shared by the test helpers created by is.New.
+type tap struct {
+	mu sync.Mutex
+	w  io.Writer
+	n  int
+}
+
+// write writes the result of the next assertion, the message lines after the first one
+// are written as the diagnostics. The # in the description are escaped,
+// otherwise the rest of the line is taken as a directive, e.g. # TODO.
+func (t *tap) write(passed bool, message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.n++
+	status := "ok"
+	if !passed {
+		status = "not ok"
+	}
+	lines := strings.Split(message, "\n")
+	fmt.Fprintf(t.w, "%s %d - %s\n", status, t.n, tapEscaper.Replace(lines[0]))
+	for _, line := range lines[1:] {
+		fmt.Fprintf(t.w, "# %s\n", line)
+	}
+}
+
+// jsonRecord is the failure written by JSONReport.
+type jsonRecord struct {
+	Assert  string `json:"assert"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// report writes the failure to the writer of JSONReport, if any.
+func (is *Is) report(skip int, prefix, message, comment string) {
+	if is.jsonReport == nil {
+		return
+	}
+
+	file, line := is.caller(skip)
+	json.NewEncoder(is.jsonReport).Encode(jsonRecord{
+		Assert:  strings.TrimPrefix(prefix, "is."),
+		File:    filepath.Base(file),
+		Line:    line,
+		Message: message,
+		Comment: strings.TrimPrefix(comment, "// "),
+	})
+}
+
+// prefixed replaces the is of prefix, e.g. is.Equal, by the namespace of WithPrefix.
+func (c *config) prefixed(prefix string) string {
+	if c.namespace == "" {
+		return prefix
+	}
+	return c.namespace + strings.TrimPrefix(prefix, "is")
+}
+
+// locate returns the file:line prefix of the assertion line for WithLocation, e.g. girl_test.go:12: .
+func (is *Is) locate(skip int) string {
+	_, file, line, _ := runtime.Caller(skip) // level of function call to the actual test
+	return fmt.Sprintf("%s:%d: ", filepath.Base(file), line)
+}
+
+// log writes message to the output of WithOutput, or logs it by t.Log by default.
+func (is *Is) log(message string) {
+	if is.output != nil {
+		fmt.Fprintln(is.output, message)
+		return
+	}
+	is.Log(message)
+}
+
+// failNow is t.FailNow, or t.Fail in relaxed mode.
+func (is *Is) failNow() {
+	if is.relaxed {
+		is.Fail()
+		return
+	}
+	is.FailNow()
+}
+
+// failures is the collection of failure messages, safe for concurrent use.
+type failures struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+// add adds message to the collection, nil collection ignores it.
+func (f *failures) add(message string) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, message)
+}
+
+// list returns a copy of the collected messages.
+func (f *failures) list() []string {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.messages...)
+}
+
+// pass reports the passed assertion, skip and prefix are the same as logf.
+func (is *Is) pass(skip int, prefix string) {
+	skip += is.extraSkip
+	is.observe(skip, prefix, true, "")
+	if is.tap != nil {
+		msg := is.prefixed(prefix)
+		if comment := is.loadComment(skip); comment != "" {
+			msg += " " + comment
+		}
+		is.tap.write(true, msg)
+	}
+}
+
+// observe sends the result of the assertion to the observer, if any.
+func (is *Is) observe(skip int, prefix string, passed bool, message string) {
+	if is.observer == nil {
+		return
+	}
+
+	file, line := is.caller(skip)
+	is.observer(AssertionEvent{
+		Method:  strings.TrimPrefix(prefix, "is."),
+		File:    file,
+		Line:    line,
+		Passed:  passed,
+		Message: message,
+	})
+}
+
+// withinTimeout reports the result of compare. ok is false
+// if the comparison exceeds the compare timeout, if any.
+func (is *Is) withinTimeout(compare func() bool) (equal, ok bool) {
+	if is.compareTimeout <= 0 {
+		return compare(), true
+	}
+
+	done := make(chan bool, 1) // buffered so the goroutine can exit after timeout
+	go func() { done <- compare() }()
+
+	timer := time.NewTimer(is.compareTimeout)
+	defer timer.Stop()
+
+	select {
+	case equal = <-done:
+		return equal, true
+	case <-timer.C:
+		return false, false
+	}
+}
+
+// marshalBinary marshals a and b if both implement encoding.BinaryMarshaler.
+// ok is false if they don't or either fails to marshal.
+func marshalBinary(a, b interface{}) (da, db []byte, ok bool) {
+	ma, okA := a.(encoding.BinaryMarshaler)
+	mb, okB := b.(encoding.BinaryMarshaler)
+	if !okA || !okB {
+		return nil, nil, false
+	}
+
+	da, errA := ma.MarshalBinary()
+	db, errB := mb.MarshalBinary()
+	if errA != nil || errB != nil {
+		return nil, nil, false
+	}
+	return da, db, true
+}
+
+// isElemOf reports whether elem has the element type of the slice or array s.
+func isElemOf(elem, s interface{}) bool {
+	typ := reflect.TypeOf(s)
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return typ.Elem() == reflect.TypeOf(elem)
+	}
+	return false
+}
+
+// isDecodedStruct reports whether m is a map with string keys
+// whose keys all match the field names of the struct st, as if m were decoded from st.
+// The field names match the keys the way encoding/json does, by json tag or case-insensitively.
+func isDecodedStruct(m, st interface{}) bool {
+	mv, sv := reflect.ValueOf(m), reflect.ValueOf(st)
+	if mv.Kind() != reflect.Map || mv.Type().Key().Kind() != reflect.String || mv.Len() == 0 {
+		return false
+	}
+	if sv.Kind() != reflect.Struct {
+		return false
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < sv.NumField(); i++ {
+		field := sv.Type().Field(i)
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			name = tag
+		}
+		names[strings.ToLower(name)] = true
+	}
+	for _, key := range mv.MapKeys() {
+		if !names[strings.ToLower(key.String())] {
+			return false
+		}
+	}
+	return true
+}
+
+// commonInterfaces are the interfaces checked by sharedInterface in order.
+var commonInterfaces = []reflect.Type{
+	reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+	reflect.TypeOf((*io.Reader)(nil)).Elem(),
+	reflect.TypeOf((*io.Writer)(nil)).Elem(),
+	reflect.TypeOf((*io.Closer)(nil)).Elem(),
+}
+
+// sharedInterface returns the first of commonInterfaces that both a and b implement, if any.
+func sharedInterface(a, b interface{}) (reflect.Type, bool) {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	for _, iface := range commonInterfaces {
+		if ta.Implements(iface) && tb.Implements(iface) {
+			return iface, true
+		}
+	}
+	return nil, false
+}
+
+// initialize calls the Init method of v, if any, to force its lazy initialization.
+// The non-pointer v is initialized on its copy which is returned.
+func initialize(v interface{}) interface{} {
+	if v == nil {
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	ptr := rv
+	if rv.Kind() != reflect.Ptr {
+		ptr = reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+	} else if rv.IsNil() {
+		return v
+	}
+
+	method := ptr.MethodByName("Init")
+	if !method.IsValid() || method.Type().NumIn() != 0 {
+		return v
+	}
+	method.Call(nil)
+
+	if rv.Kind() != reflect.Ptr {
+		return ptr.Elem().Interface()
+	}
+	return v
+}
+
+// poll checks condition right away and then every interval until it returns true
+// or timeout elapses, it reports whether condition returned true.
+func poll(condition func() bool, timeout, interval time.Duration) bool {
+	if condition() {
+		return true
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-deadline.C:
+			return false
+		case <-tick.C:
+			if condition() {
+				return true
+			}
+		}
+	}
+}
+
+// receive receives a value from the channel ch within timeout, ok is false if ch is closed
+// and received is false if nothing arrives. It panics if ch isn't a channel to receive from.
+func receive(ch interface{}, timeout time.Duration) (v reflect.Value, ok, received bool) {
+	c := reflect.ValueOf(ch)
+	if c.Kind() != reflect.Chan || c.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic(fmt.Sprintf("is: %T is not a receivable channel", ch))
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	chosen, v, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: c},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	if chosen == 1 {
+		return reflect.Value{}, false, false
+	}
+	return v, ok, true
+}
+
+// leakTimeout is how long is.NoGoroutineLeak waits for the goroutines to exit.
+const leakTimeout = time.Second
+
+// goroutines returns the stacks of the running goroutines keyed by their header,
+// e.g. goroutine 7, excluding the calling goroutine.
+func goroutines() map[string]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[string]string)
+	for i, stack := range strings.Split(strings.TrimSpace(string(buf)), "\n\n") {
+		if i == 0 {
+			continue // the calling goroutine is dumped first
+		}
+		if id := strings.SplitN(stack, " [", 2)[0]; strings.HasPrefix(id, "goroutine ") {
+			stacks[id] = stack
+		}
+	}
+	return stacks
+}
+
+// normalize converts v to the canonical form of is.NormalizedEqual.
+// The nil and empty containers, i.e. the slices, arrays and maps,
+// and the nil pointers and interfaces are all nil.
+func normalize(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return normalize(v.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u)
+		}
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f)
+		}
+		return f
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil
+		}
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = normalize(v.Index(i))
+		}
+		return s
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(normalize(iter.Key()))] = normalize(iter.Value())
+		}
+		return m
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Type().Field(i); field.PkgPath == "" {
+				m[field.Name] = normalize(v.Field(i))
+			}
+		}
+		if len(m) == 0 && v.NumField() > 0 && v.CanInterface() {
+			return v.Interface() // e.g. time.Time has no exported fields to compare
+		}
+		return m
+	}
+
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
+
+// signature renders the signature of the function f, it panics if f isn't a function.
+func signature(f interface{}) string {
+	typ := reflect.TypeOf(f)
+	if typ == nil || typ.Kind() != reflect.Func {
+		panic(fmt.Sprintf("is: %T is not a func", f))
+	}
+
+	in := make([]string, typ.NumIn())
+	for i := range in {
+		in[i] = typ.In(i).String()
+	}
+	if typ.IsVariadic() {
+		in[len(in)-1] = "..." + typ.In(len(in)-1).Elem().String()
+	}
+
+	out := make([]string, typ.NumOut())
+	for i := range out {
+		out[i] = typ.Out(i).String()
+	}
+
+	sig := "func(" + strings.Join(in, ", ") + ")"
+	switch len(out) {
+	case 0:
+		return sig
+	case 1:
+		return sig + " " + out[0]
+	}
+	return sig + " (" + strings.Join(out, ", ") + ")"
+}
+
+// decodeJSON decodes the JSON document s.
+func decodeJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// decodeDocuments decodes the JSON documents a and b for is.JSONEqual and is.JSONEqualBytes,
+// coerced under is.LooseJSON, along with the suffix noting the coercion in the failure message.
+func (c *config) decodeDocuments(a, b string) (va, vb interface{}, coerced string, err error) {
+	if va, err = decodeJSON(a); err != nil {
+		return nil, nil, "", fmt.Errorf("invalid JSON in a: %s", err)
+	}
+	if vb, err = decodeJSON(b); err != nil {
+		return nil, nil, "", fmt.Errorf("invalid JSON in b: %s", err)
+	}
+	if c.looseJSON {
+		va, vb = coerceJSON(va), coerceJSON(vb)
+		coerced = " (coerced)"
+	}
+	return va, vb, coerced, nil
+}
+
+// encodeJSON encodes the decoded JSON document v in the compact form with sorted object keys.
+func encodeJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// indentJSON encodes the decoded JSON document v indented by two spaces with sorted object keys,
+// the lines after the first one are prefixed by a tab to line up in the failure message.
+func indentJSON(v interface{}) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(encodeJSON(v)), "\t", "  "); err != nil {
+		return encodeJSON(v)
+	}
+	return buf.String()
+}
+
+// jsonDiff returns the path of the first difference of the decoded JSON documents a and b
+// below path, along with the description of the difference, e.g. $.items[1].id: 2 != 3.
+// The object keys are visited in the sorted order.
+func jsonDiff(a, b interface{}, path string) (string, string) {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(a)+len(b))
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range b {
+			if _, ok := a[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			va, okA := a[k]
+			vb, okB := b[k]
+			switch {
+			case !okA:
+				return path + "." + k, "missing in a"
+			case !okB:
+				return path + "." + k, "missing in b"
+			case !reflect.DeepEqual(va, vb):
+				return jsonDiff(va, vb, path+"."+k)
+			}
+		}
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		if len(a) != len(b) {
+			return path, fmt.Sprintf("len %d != len %d", len(a), len(b))
+		}
+
+		for i := range a {
+			if !reflect.DeepEqual(a[i], b[i]) {
+				return jsonDiff(a[i], b[i], fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+	return path, encodeJSON(a) + " != " + encodeJSON(b)
+}
+
+// coerceJSON converts the strings of the decoded JSON document v
+// that encode a number or a boolean into that number or boolean.
+func coerceJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		var coerced interface{}
+		if err := json.Unmarshal([]byte(v), &coerced); err == nil {
+			switch coerced.(type) {
+			case float64, bool:
+				return coerced
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = coerceJSON(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = coerceJSON(v[k])
+		}
+	}
+	return v
+}
+
+// isList reports whether v is a slice or an array.
+func isList(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// diffElements returns the elements of a missing from b as extra and vice versa as missing,
+// counting the duplicates.
+func diffElements(a, b reflect.Value) (extra, missing []interface{}) {
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if !matched[j] && reflect.DeepEqual(a.Index(i).Interface(), b.Index(j).Interface()) {
+				matched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, a.Index(i).Interface())
+		}
+	}
+
+	for j := 0; j < b.Len(); j++ {
+		if !matched[j] {
+			missing = append(missing, b.Index(j).Interface())
+		}
+	}
+	return extra, missing
+}
+
+// missing renders the elements of part missing from whole, or empty if none.
+// It's not ok if whole and part aren't both slices or arrays, or both maps.
+func (c *config) missing(whole, part interface{}) (string, bool) {
+	w, p := reflect.ValueOf(whole), reflect.ValueOf(part)
+	switch {
+	case isList(whole) && isList(part):
+		var missing []interface{}
+		for i := 0; i < p.Len(); i++ {
+			if !containsElem(w, p.Index(i).Interface()) {
+				missing = append(missing, p.Index(i).Interface())
+			}
+		}
+		if len(missing) == 0 {
+			return "", true
+		}
+		return c.formatList(missing), true
+	case w.Kind() == reflect.Map && p.Kind() == reflect.Map:
+		missing := reflect.MakeMap(p.Type())
+		iter := p.MapRange()
+		for iter.Next() {
+			if !iter.Key().Type().AssignableTo(w.Type().Key()) {
+				missing.SetMapIndex(iter.Key(), iter.Value())
+				continue
+			}
+			v := w.MapIndex(iter.Key())
+			if !v.IsValid() || !reflect.DeepEqual(v.Interface(), iter.Value().Interface()) {
+				missing.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		if missing.Len() == 0 {
+			return "", true
+		}
+		return c.format(missing.Interface()), true
+	}
+	return "", false
+}
+
+// containsElem reports whether the slice or array s contains elem.
+func containsElem(s reflect.Value, elem interface{}) bool {
+	for i := 0; i < s.Len(); i++ {
+		if reflect.DeepEqual(s.Index(i).Interface(), elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatList renders the elements the way is.Equal prints a slice.
+func (c *config) formatList(elems []interface{}) string {
+	s := make([]string, len(elems))
+	for i, e := range elems {
+		s[i] = c.format(e)
+	}
+	return "[" + strings.Join(s, " ") + "]"
+}
+
+// sameInts reports whether a and b have the same elements regardless of their order.
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	count := make(map[int]int, len(a))
+	for _, v := range a {
+		count[v]++
+	}
+	for _, v := range b {
+		if count[v] == 0 {
+			return false
+		}
+		count[v]--
+	}
+	return true
+}
+
+// typeName returns the name of the data type of v, or <nil> for the untyped nil.
+func typeName(v interface{}) string {
 	if isNil(v) {
 		return "<nil>"
 	}
-	return fmt.Sprintf("%[1]T(%[1]v)", v)
+	return reflect.TypeOf(v).String()
+}
+
+func toInterfaces(s []string) []interface{} {
+	v := make([]interface{}, len(s))
+	for i := range s {
+		v[i] = s[i]
+	}
+	return v
+}
+
+// formatTime formats t in RFC 3339 with the nanoseconds, or <nil> if t is nil.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d == math.MinInt64 {
+		// time.Sub saturates to the minimum duration which has no positive counterpart
+		return math.MaxInt64
+	}
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ansi matches the ANSI control sequences, e.g. the color codes.
+var ansi = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]`)
+
+func stripANSI(s string) string {
+	return ansi.ReplaceAllString(s, "")
+}
+
+func (c *config) valWithType(v interface{}) string {
+	if isNil(v) {
+		return "<nil>"
+	}
+	if s, ok := flagNames(v); ok {
+		return s
+	}
+	return fmt.Sprintf("%T(%s)", v, c.format(v))
 }
 
 func isNil(obj interface{}) bool {
@@ -100,12 +1091,240 @@ func isNil(obj interface{}) bool {
 	return false
 }
 
+// equal reports whether a and b are equal the way is.Equal compares them,
+// ok is false if the comparison exceeded the compare timeout.
+func (is *Is) equal(a, b interface{}) (equal, ok bool) {
+	if eq, ok := is.registeredEqual(a, b); ok {
+		return is.withinTimeout(func() bool { return eq(a, b) })
+	}
+
+	if ea, eb, ok := bothErrors(a, b); ok && (errors.Is(ea, eb) || errors.Is(eb, ea)) {
+		return true, true
+	}
+
+	equal, ok = is.withinTimeout(func() bool { return reflect.DeepEqual(a, b) })
+	if equal || !ok {
+		return equal, ok
+	}
+
+	if len(is.cmpOptions) > 0 && cmpEqual(a, b, is.cmpOptions) {
+		return true, true
+	}
+
+	if isNil(a) || isNil(b) || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false, true
+	}
+
+	if ba, ok := unbox(a); ok {
+		bb, _ := unbox(b)
+		return ba.typeName == bb.typeName && reflect.DeepEqual(ba.inner, bb.inner), true
+	}
+
+	if da, db, ok := marshalBinary(a, b); ok {
+		return bytes.Equal(da, db), true
+	}
+	return false, true
+}
+
+// bothErrors returns a and b as errors if both are the non-nil errors.
+func bothErrors(a, b interface{}) (ea, eb error, ok bool) {
+	ea, okA := a.(error)
+	eb, okB := b.(error)
+	if !okA || !okB || isNilValue(a) || isNilValue(b) {
+		return nil, nil, false
+	}
+	return ea, eb, true
+}
+
+// notPointers describes the first one of a and b that isn't a pointer, ok is true if both are.
+func (c *config) notPointers(a, b interface{}) (msg string, ok bool) {
+	for _, v := range []interface{}{a, b} {
+		if reflect.ValueOf(v).Kind() != reflect.Ptr {
+			return c.valWithType(v) + " is not a pointer", false
+		}
+	}
+	return "", true
+}
+
+// isNilValue reports whether v is nil, including the typed nil of nillable kinds.
+func isNilValue(v interface{}) bool {
+	if isNil(v) {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// isEmpty reports whether v is nil, of zero length if v is a slice, map, channel or string,
+// or the zero value of its type otherwise.
+func isEmpty(v interface{}) bool {
+	if isNil(v) {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Chan, reflect.String:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// isZero reports whether v is nil or the zero value of its type.
+func isZero(v interface{}) bool {
+	return isNil(v) || reflect.ValueOf(v).IsZero()
+}
+
+// hasLen reports whether v is of a kind supported by reflect.Value.Len.
+func hasLen(v interface{}) bool {
+	if isNil(v) {
+		return false
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// mapIndex returns the value of key in the map m and whether the key exists,
+// or the failure message if m is not a map or key is not of its key type.
+func (c *config) mapIndex(m, key interface{}) (v interface{}, ok bool, msg string) {
+	rm := reflect.ValueOf(m)
+	if rm.Kind() != reflect.Map {
+		return nil, false, fmt.Sprintf("%s is not a map", c.valWithType(m))
+	}
+
+	typ := rm.Type().Key()
+	rk := reflect.ValueOf(key)
+	switch k := typ.Kind(); {
+	case !rk.IsValid() && (k == reflect.Interface || k == reflect.Ptr || k == reflect.Chan):
+		rk = reflect.Zero(typ)
+	case !rk.IsValid() || !rk.Type().AssignableTo(typ):
+		return nil, false, fmt.Sprintf("%s is not of the key type %s", c.valWithType(key), typ)
+	}
+
+	rv := rm.MapIndex(rk)
+	if !rv.IsValid() {
+		return nil, false, ""
+	}
+	return rv.Interface(), true, ""
+}
+
+// mapKey renders key of is.MapHasKey and is.MapValue, quoted if it is a string.
+func (c *config) mapKey(key interface{}) string {
+	if s, ok := key.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return c.format(key)
+}
+
+// caller returns the file and line of the actual test.
+func (is *Is) caller(skip int) (file string, line int) {
+	_, file, line, _ = runtime.Caller(skip + 1) // one more level for observe and report
+	return file, line
+}
+
+// frames resolves the caller at each skip level from 0 to 6 for is.DebugCaller,
+// the levels are aligned with the skip of the assertions.
+func (is *Is) frames() []string {
+	var frames []string
+	for skip := 0; skip <= 6; skip++ {
+		frames = append(frames, fmt.Sprintf("\tskip %d: %s", skip, frame(skip)))
+	}
+	return frames
+}
+
+func frame(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "<unknown>"
+	}
+
+	function := "<unknown>"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		function = fn.Name()
+	}
+	return fmt.Sprintf("%s:%d %s", filepath.Base(file), line, function)
+}
+
 func (is *Is) loadComment(skip int) string {
 	_, file, line, _ := runtime.Caller(skip) // level of function call to the actual test
-	return comments[file][line]
+	return loadSource(file).comments[line]
 }
 
-func (is *Is) loadArgument() string {
-	_, file, line, _ := runtime.Caller(2) // level of function call to the actual test
-	return arguments[file][line]
+// loadArgument returns the source of the argument of the call to is.True, is.False or is.Truef,
+// or the source of the call to the wrapping function of WithExtraSkip.
+// The calls sharing the line are told apart by their enclosing function, e.g. a closure each,
+// otherwise all of their arguments are the candidates.
+func (is *Is) loadArgument(c call) argument {
+	if is.extraSkip > 0 {
+		// the caller line has the call to the wrapping function instead
+		return argument{expr: loadSource(c.file).call(c.line)}
+	}
+
+	args := loadSource(c.file).arguments[c.line]
+	switch len(args) {
+	case 0:
+		return argument{}
+	case 1:
+		return args[0]
+	}
+
+	var candidates []argument
+	function := sourceName(c.function)
+	for _, arg := range args {
+		if arg.function == function {
+			candidates = append(candidates, arg)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		candidates = args
+	case 1:
+		return candidates[0]
+	}
+
+	exprs := make([]string, len(candidates))
+	for i, arg := range candidates {
+		exprs[i] = arg.expr
+	}
+	return argument{expr: "one of: " + strings.Join(exprs, "; ")}
+}
+
+// joinedErrors is the list of the errors joined by errors.Join.
+type joinedErrors []error
+
+func (errs joinedErrors) String() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return "[" + strings.Join(msgs, "; ") + "]"
+}
+
+// joined returns the errors joined inside err by repeated unwrapping, the nested joins are flattened.
+// It returns nil if err doesn't wrap the joined errors.
+func joined(err error) joinedErrors {
+	for err != nil {
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			var errs joinedErrors
+			for _, e := range multi.Unwrap() {
+				if inner := joined(e); inner != nil {
+					errs = append(errs, inner...)
+					continue
+				}
+				errs = append(errs, e)
+			}
+			return errs
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
 }
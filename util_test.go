@@ -3,7 +3,10 @@ package is_test
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
+
+	assert "github.com/billyzaelani/is"
 )
 
 var (
@@ -13,6 +16,25 @@ var (
 	errWrong = errors.New("something's wrong")
 )
 
+// testing.TB satisfies T so is can be used in the benchmarks and fuzz targets.
+var (
+	_ assert.T = (*testing.T)(nil)
+	_ assert.T = (*testing.B)(nil)
+	_ assert.T = (*testing.F)(nil)
+	_ assert.T = testing.TB(nil)
+)
+
+// mockTB is the testing.TB stand-in recording the calls to the subset of T.
+type mockTB struct {
+	testing.TB
+	mockT
+}
+
+func (m *mockTB) Fail()                   { m.mockT.Fail() }
+func (m *mockTB) FailNow()                { m.mockT.FailNow() }
+func (m *mockTB) Log(args ...interface{}) { m.mockT.Log(args...) }
+func (m *mockTB) Helper()                 { m.mockT.Helper() }
+
 type mockT struct {
 	state       failState
 	msg         string
@@ -57,3 +79,31 @@ func assertState(t *testing.T, got, want failState) {
 type QueryError struct{ Query string }
 
 func (e *QueryError) Error() string { return "query: " + e.Query }
+
+// BenchmarkFirstAssertion measures the latency of the first failing assertion,
+// lazy parses only the file of the assertion while eager parses every test file
+// in the package upfront as the former directory walk did.
+func BenchmarkFirstAssertion(b *testing.B) {
+	files, err := filepath.Glob("*_test.go")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("eager", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			assert.ResetSources()
+			for _, file := range files {
+				path, _ := filepath.Abs(file)
+				assert.LoadedArgument(path, 0)
+			}
+			assert.New(new(mockT)).True(1 == 2)
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			assert.ResetSources()
+			assert.New(new(mockT)).True(1 == 2)
+		}
+	})
+}